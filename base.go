@@ -16,6 +16,8 @@ package bitty
 	limitations under the License.
 */
 
+import "time"
+
 // Symbolic enables Unit to provide a standard, exponent, and symbol
 type Symbolic interface {
 	// Standard returns the standard for the unit as defined by the SI brochure,
@@ -54,17 +56,60 @@ type Calculator interface {
 	Add(Unit) Unit
 	// Subtract attempts to subtract one Unit from another
 	Subtract(Unit) Unit
-	// Multiply attempts to multiply one Unit by another
+	// Multiply attempts to multiply one Unit by another. The product of two
+	// Units has no meaningful size, so a 0 Byte Unit is returned.
+	//
+	// Deprecated: use MultiplyE, or Scale to multiply by a scalar
 	Multiply(Unit) Unit
-	// Divide attempts to divide one Unit from another
+	// Divide attempts to divide one Unit from another. The quotient of two
+	// Units is dimensionless, so a 0 Byte Unit is returned.
+	//
+	// Deprecated: use DivideE, or Ratio to divide by a Unit
 	Divide(Unit) Unit
 }
 
+// CheckedCalculator enables Units to be calculated against each other while
+// reporting failures, where Calculator falls back to one of the operands or a
+// 0 Byte Unit
+type CheckedCalculator interface {
+	// AddE attempts to add one Unit to another, or returns an error
+	AddE(Unit) (Unit, error)
+	// SubtractE attempts to subtract one Unit from another, or returns an error
+	SubtractE(Unit) (Unit, error)
+	// MultiplyE attempts to multiply one Unit by another, or returns an error
+	MultiplyE(Unit) (Unit, error)
+	// DivideE attempts to divide one Unit from another, or returns an error
+	DivideE(Unit) (Unit, error)
+}
+
+// Scaler enables Units to be multiplied or divided by dimensionless values
+type Scaler interface {
+	// Scale returns a new Unit with the same symbol and the size multiplied
+	// by a scalar, i.e. 3 × 4 GiB = 12 GiB
+	Scale(float64) Unit
+	// Ratio returns the dimensionless ratio of one Unit to another, i.e.
+	// 1 GiB / 512 MiB = 2
+	Ratio(Unit) (float64, error)
+}
+
+// Transferer enables Units to be related to data rates over time
+type Transferer interface {
+	// Over returns the Rate of the Unit transferred over a positive duration,
+	// i.e. 1 GiB over 8 seconds = 1 GiB/8s, which PerSecond measures as
+	// 0.125 GiB/s. Durations which are not positive return the zero Rate
+	Over(time.Duration) Rate
+	// TransferTime returns the time taken to transfer the Unit at a Rate, i.e.
+	// 1 GB at 100 Mbps = 80s
+	TransferTime(Rate) time.Duration
+}
+
 // Unit enables Unit kinds to interact with each other
 type Unit interface {
 	Symbolic
 	Sizer
 	Calculator
+	Scaler
+	Transferer
 }
 
 // BaseUnitSymbolPair represents the bit and byte pairs
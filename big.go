@@ -0,0 +1,310 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// BigUnit handles binary and decimal units of any supported standard with an
+// arbitrary precision size, avoiding the float64 rounding of IECUnit and SIUnit
+type BigUnit struct {
+	// size is the exact size as measured by the symbol (UnitSymbol)
+	size     *big.Rat
+	standard UnitStandard
+	symbol   UnitSymbol
+	exponent int
+}
+
+// NewBigUnit returns a *BigUnit with the proper exponent included. The size is
+// copied, so later changes to it do not affect the BigUnit
+func NewBigUnit(std UnitStandard, size *big.Rat, sym UnitSymbol) (*BigUnit, error) {
+	if _, ok := FindUnitSymbolPairByExponent(std, 0); !ok {
+		return nil, NewErrUnitStandardNotSupported(std)
+	}
+	if _, ok := unitSymbolByteRat(std, sym); !ok {
+		return nil, NewErrUnitSymbolNotSupported(sym)
+	}
+	pair, _ := FindUnitSymbolPairBySymbol(std, sym)
+	r := new(big.Rat)
+	if size != nil {
+		r.Set(size)
+	}
+	return &BigUnit{r, std, sym, pair.Exponent()}, nil
+}
+
+// NewBigUnitFromUnit returns a *BigUnit holding exactly the size, standard,
+// and symbol of another Unit
+func NewBigUnitFromUnit(u Unit) (*BigUnit, error) {
+	if b, ok := u.(*BigUnit); ok {
+		return NewBigUnit(b.standard, b.size, b.symbol)
+	}
+	size := new(big.Rat).SetFloat64(u.Size())
+	if size == nil {
+		return nil, ErrUnitOverflow
+	}
+	return NewBigUnit(u.Standard(), size, u.Symbol())
+}
+
+// newBigUnitFromBytes returns a *BigUnit of a given standard and symbol holding
+// an exact number of bytes
+func newBigUnitFromBytes(std UnitStandard, sym UnitSymbol, bytes *big.Rat) (*BigUnit, error) {
+	r, ok := unitSymbolByteRat(std, sym)
+	if !ok {
+		return nil, NewErrUnitSymbolNotSupported(sym)
+	}
+	return NewBigUnit(std, new(big.Rat).Quo(bytes, r), sym)
+}
+
+// Standard returns the UnitStandard of a BigUnit
+func (u *BigUnit) Standard() UnitStandard {
+	return u.standard
+}
+
+// Exponent returns the exponent of a BigUnit
+func (u *BigUnit) Exponent() int {
+	return u.exponent
+}
+
+// Symbol returns the UnitSymbol of a BigUnit
+func (u *BigUnit) Symbol() UnitSymbol {
+	return u.symbol
+}
+
+// Size returns the size of a BigUnit, rounded to the nearest float64
+func (u *BigUnit) Size() float64 {
+	f, _ := u.Rat().Float64()
+	return f
+}
+
+// Rat returns a copy of the exact size of a BigUnit
+func (u *BigUnit) Rat() *big.Rat {
+	if u.size == nil {
+		return new(big.Rat)
+	}
+	return new(big.Rat).Set(u.size)
+}
+
+// ByteRat returns the exact size of the Unit measured in bytes, or 0 if the
+// symbol is not supported
+func (u *BigUnit) ByteRat() *big.Rat {
+	r, ok := unitSymbolByteRat(u.standard, u.symbol)
+	if !ok {
+		return new(big.Rat)
+	}
+	return r.Mul(r, u.Rat())
+}
+
+// BitSize returns the size of the Unit measured in bits
+func (u *BigUnit) BitSize() float64 {
+	f, _ := u.ByteRat().Mul(u.ByteRat(), big.NewRat(8, 1)).Float64()
+	return f
+}
+
+// ByteSize returns the size of the Unit measured in bytes
+func (u *BigUnit) ByteSize() float64 {
+	f, _ := u.ByteRat().Float64()
+	return f
+}
+
+// SizeInUnit returns the size of the Unit measured in an arbitrary UnitSymbol
+// from Bit up to YiB or YB
+func (u *BigUnit) SizeInUnit(symbol UnitSymbol) float64 {
+	std, ok := findStandardForSymbol(u.standard, symbol)
+	if !ok {
+		return float64(0)
+	}
+	r, ok := unitSymbolByteRat(std, symbol)
+	if !ok {
+		return float64(0)
+	}
+	f, _ := r.Quo(u.ByteRat(), r).Float64()
+	return f
+}
+
+// ConvertStd converts a BigUnit losslessly into another standard, measured by
+// the normalized symbol of the same kind (bit or byte) in the new standard
+func (u *BigUnit) ConvertStd(std UnitStandard) (*BigUnit, error) {
+	bits := isBitUnitSymbol(u.standard, u.symbol)
+	sym, _, ok := bestUnitSymbol(std, u.ByteRat(), bits, big.NewRat(1, 1))
+	if !ok {
+		return nil, NewErrUnitStandardNotSupported(std)
+	}
+	return newBigUnitFromBytes(std, sym, u.ByteRat())
+}
+
+// String returns the exact size and symbol of a BigUnit, i.e. "1.5 GiB", or
+// the nearest float64 size if it has no finite decimal form
+func (u *BigUnit) String() string {
+	s, _ := formatUnit(u, 'v', 0, false, false)
+	return s
+}
+
+// Format implements fmt.Formatter, supporting the verbs and flags described in
+// the package documentation
+func (u *BigUnit) Format(f fmt.State, verb rune) {
+	formatState(f, verb, u)
+}
+
+// Add attempts to add one Unit to another, keeping the symbol of the BigUnit
+func (u *BigUnit) Add(unit Unit) Unit {
+	lok, rok := ValidateSymbols(u.Symbol(), unit.Symbol())
+	if lok && !rok {
+		return u
+	}
+	if rok && !lok {
+		return unit
+	}
+	nu, err := u.AddE(unit)
+	if err != nil {
+		return u.zero()
+	}
+	return nu
+}
+
+// AddE attempts to add one Unit to another exactly, keeping the symbol of the
+// BigUnit, or returns an error if either symbol is invalid
+func (u *BigUnit) AddE(unit Unit) (Unit, error) {
+	if err := checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
+	}
+	right, ok := unitByteRat(unit)
+	if !ok {
+		return nil, ErrUnitOverflow
+	}
+	return newBigUnitFromBytes(u.standard, u.symbol, right.Add(u.ByteRat(), right))
+}
+
+// Subtract attempts to subtract one Unit from another, keeping the symbol of
+// the BigUnit
+func (u *BigUnit) Subtract(unit Unit) Unit {
+	lok, rok := ValidateSymbols(u.Symbol(), unit.Symbol())
+	if lok && !rok {
+		return u
+	}
+	if rok && !lok {
+		return unit
+	}
+	nu, err := u.SubtractE(unit)
+	if err != nil {
+		return u.zero()
+	}
+	return nu
+}
+
+// SubtractE attempts to subtract one Unit from another exactly, keeping the
+// symbol of the BigUnit, or returns an error if either symbol is invalid
+func (u *BigUnit) SubtractE(unit Unit) (Unit, error) {
+	if err := checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
+	}
+	right, ok := unitByteRat(unit)
+	if !ok {
+		return nil, ErrUnitOverflow
+	}
+	return newBigUnitFromBytes(u.standard, u.symbol, right.Sub(u.ByteRat(), right))
+}
+
+// Multiply attempts to multiply one Unit by another. As the product of two
+// Units has no meaningful size, a 0 Byte Unit is returned.
+//
+// Deprecated: use MultiplyE, which reports ErrUnitMultiplyNotSupported, or
+// Scale in order to multiply by a scalar
+func (u *BigUnit) Multiply(unit Unit) Unit {
+	return u.zero()
+}
+
+// Divide attempts to divide one Unit by another. As the quotient of two Units
+// is dimensionless, a 0 Byte Unit is returned.
+//
+// Deprecated: use DivideE, which reports ErrUnitDivideNotSupported, Ratio in
+// order to divide by a Unit, or Scale in order to divide by a scalar
+func (u *BigUnit) Divide(unit Unit) Unit {
+	return u.zero()
+}
+
+// MultiplyE returns an error, as the product of two Units has no meaningful
+// size; use Scale in order to multiply by a scalar
+func (u *BigUnit) MultiplyE(unit Unit) (Unit, error) {
+	if err := checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
+	}
+	return nil, NewErrUnitMultiplyNotSupported(u.Symbol(), unit.Symbol())
+}
+
+// DivideE returns an error, as the quotient of two Units is dimensionless; use
+// Ratio in order to divide by a Unit, or Scale in order to divide by a scalar
+func (u *BigUnit) DivideE(unit Unit) (Unit, error) {
+	if err := checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
+	}
+	return nil, NewErrUnitDivideNotSupported(u.Symbol(), unit.Symbol())
+}
+
+// Scale returns a new BigUnit with the same symbol and the size multiplied by
+// n exactly. As NaN and infinite values have no exact size, they result in a
+// size of 0
+func (u *BigUnit) Scale(n float64) Unit {
+	r := new(big.Rat).SetFloat64(n)
+	if r == nil {
+		r = new(big.Rat)
+	}
+	return u.ScaleRat(r)
+}
+
+// ScaleRat returns a new BigUnit with the same symbol and the size multiplied
+// by an exact rational number
+func (u *BigUnit) ScaleRat(n *big.Rat) *BigUnit {
+	return &BigUnit{new(big.Rat).Mul(u.Rat(), n), u.standard, u.symbol, u.exponent}
+}
+
+// Ratio returns the dimensionless ratio of the BigUnit to another Unit, which
+// is calculated exactly before rounding to the nearest float64
+func (u *BigUnit) Ratio(unit Unit) (float64, error) {
+	if err := checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return 0, err
+	}
+	right, ok := unitByteRat(unit)
+	if !ok {
+		return 0, ErrUnitOverflow
+	}
+	if right.Sign() == 0 {
+		return 0, newArithmeticError("/", u, unit, ErrUnitDivideByZero)
+	}
+	f, _ := right.Quo(u.ByteRat(), right).Float64()
+	return f, nil
+}
+
+// Over returns the Rate of the BigUnit transferred over a duration, i.e. the
+// BigUnit per d, or the zero Rate if d is not positive, as by NewRate
+func (u *BigUnit) Over(d time.Duration) Rate {
+	r, _ := NewRate(u, d)
+	return r
+}
+
+// TransferTime returns the time taken to transfer the BigUnit at a Rate, or the
+// maximum time.Duration if the Rate is not positive
+func (u *BigUnit) TransferTime(r Rate) time.Duration {
+	return transferTime(u, r)
+}
+
+// zero returns a 0 Byte BigUnit of the same standard
+func (u *BigUnit) zero() *BigUnit {
+	return &BigUnit{new(big.Rat), u.standard, Byte, 0}
+}
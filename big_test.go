@@ -0,0 +1,144 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleNewBigUnit() {
+	a, _ := NewBigUnit(IEC, big.NewRat(3, 2), YiB)
+	_, err := NewBigUnit(IEC, big.NewRat(1, 1), MB)
+	fmt.Println(a.Rat(), a.Symbol(), a.Exponent())
+	fmt.Println(a.ByteRat().FloatString(0))
+	fmt.Println(err)
+	// Output:
+	// 3/2 YiB 8
+	// 1813388729421943762059264
+	// unit symbol not supported: MB
+}
+
+func ExampleBigUnit_Add() {
+	a, _ := NewBigUnit(IEC, big.NewRat(1, 1), YiB)
+	b, _ := NewBigUnit(IEC, big.NewRat(1, 1), Byte)
+	c := a.Add(b).(*BigUnit)
+	d := c.Subtract(a).(*BigUnit)
+	fmt.Println(c.ByteRat().FloatString(0))
+	fmt.Println(d.ByteRat().FloatString(0), d.Symbol())
+	// Output:
+	// 1208925819614629174706177
+	// 1 YiB
+}
+
+func ExampleBigUnit_ConvertStd() {
+	a, _ := NewBigUnit(IEC, big.NewRat(1, 1), YiB)
+	b, _ := ConvertUnitStd(a, SI)
+	c, _ := ConvertUnitStd(b, IEC)
+	fmt.Println(b.(*BigUnit).Rat().FloatString(24), b.Symbol())
+	fmt.Println(c.(*BigUnit).Rat().RatString(), c.Symbol())
+	// Output:
+	// 1.208925819614629174706176 YB
+	// 1 YiB
+}
+
+func TestNewBigUnit(t *testing.T) {
+	size := big.NewRat(5, 1)
+	a, err := NewBigUnit(SI, size, TB)
+	assert.NoError(t, err)
+	size.SetInt64(6)
+	assert.Equal(t, big.NewRat(5, 1), a.Rat(), "size is copied")
+	assert.Equal(t, SI, a.Standard())
+	assert.Equal(t, 12, a.Exponent())
+
+	z, err := NewBigUnit(IEC, nil, Byte)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, z.Rat().Sign())
+
+	_, err = NewBigUnit(UnitStandard(50), size, MB)
+	assert.EqualError(t, err, NewErrUnitStandardNotSupported(UnitStandard(50)).Error())
+	_, err = NewBigUnit(SI, size, UnitSymbol("FooBar"))
+	assert.EqualError(t, err, "unit symbol not supported: FooBar")
+
+	b, err := NewBigUnitFromUnit(&SIUnit{1.5, GB, 9})
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewRat(3, 2), b.Rat())
+	assert.Equal(t, GB, b.Symbol())
+}
+
+func TestBigUnit_Sizer(t *testing.T) {
+	a, _ := NewBigUnit(IEC, big.NewRat(10, 1), MiB)
+	b, _ := NewBigUnit(IEC, big.NewRat(10, 1), Mib)
+	assert.Equal(t, float64(10), a.Size())
+	assert.Equal(t, float64(10485760), a.ByteSize())
+	assert.Equal(t, float64(83886080), a.BitSize())
+	assert.Equal(t, float64(1310720), b.ByteSize())
+	assert.Equal(t, float64(10485760), b.BitSize())
+	assert.Equal(t, float64(10240), a.SizeInUnit(KiB))
+	assert.Equal(t, float64(80), a.SizeInUnit(Mib))
+	assert.Equal(t, 10.48576, a.SizeInUnit(MB))
+	assert.Equal(t, float64(0), a.SizeInUnit(UnitSymbol("FooBar")))
+}
+
+func TestBigUnit_Calculator(t *testing.T) {
+	var _ CheckedCalculator = &BigUnit{}
+	a, _ := NewBigUnit(SI, big.NewRat(1, 3), YB)
+	b := &SIUnit{1, kB, 3}
+	bu := &SIUnit{1, UnitSymbol("FooBar"), 30}
+
+	sum, err := a.AddE(b)
+	assert.NoError(t, err)
+	expected, _ := new(big.Rat).SetString("1e-21")
+	expected.Add(expected, big.NewRat(1, 3))
+	assert.Equal(t, expected, sum.(*BigUnit).Rat())
+	diff, err := sum.(*BigUnit).SubtractE(b)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewRat(1, 3), diff.(*BigUnit).Rat())
+
+	// Fallbacks match the other Calculator implementations
+	assert.Equal(t, a, a.Add(bu))
+	assert.Equal(t, a, a.Subtract(bu))
+	assert.Equal(t, a.zero(), a.Multiply(b))
+	assert.Equal(t, a.zero(), a.Divide(b))
+	for _, f := range []func(Unit) (Unit, error){a.AddE, a.SubtractE, a.MultiplyE, a.DivideE} {
+		u, err := f(bu)
+		assert.Nil(t, u)
+		assert.EqualError(t, err, "unit symbol not supported: FooBar")
+	}
+	_, err = a.MultiplyE(b)
+	assert.EqualError(t, err, "unit multiplication by unit not supported: YB * kB")
+	_, err = a.DivideE(b)
+	assert.EqualError(t, err, "unit division by unit not supported: YB / kB")
+}
+
+func TestBigUnit_Scaler(t *testing.T) {
+	a, _ := NewBigUnit(IEC, big.NewRat(3, 1), YiB)
+	b := a.Scale(1.5).(*BigUnit)
+	assert.Equal(t, big.NewRat(9, 2), b.Rat())
+	assert.Equal(t, YiB, b.Symbol())
+	assert.Equal(t, big.NewRat(1, 3), a.ScaleRat(big.NewRat(1, 9)).Rat())
+
+	r, err := a.Ratio(&IECUnit{1, ZiB, 7})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(3072), r)
+	_, err = a.Ratio(&IECUnit{0, ZiB, 7})
+	assert.True(t, errors.Is(err, ErrUnitDivideByZero))
+}
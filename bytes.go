@@ -0,0 +1,178 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Bytes represents an exact quantity of bytes as an int64 count, much like
+// time.Duration does for nanoseconds. The largest representable quantity is
+// approximately 8 EiB (or 9.2 EB).
+type Bytes int64
+
+// Common quantities of Bytes. The constants are named after the long names
+// of their UnitSymbols, which already use the short names (KiB, GB, ...);
+// UnitSymbol.Bytes gives the same quantities by short name, i.e.
+// 4 * bitty.GiB.Bytes() is 4 * bitty.Gibibyte.
+//
+// To count the number of units in a Bytes, divide:
+//
+//	b := 4 * bitty.Gibibyte
+//	fmt.Print(int64(b / bitty.Mebibyte)) // prints 4096
+const (
+	Kibibyte Bytes = 1 << (10 * (iota + 1))
+	Mebibyte
+	Gibibyte
+	Tebibyte
+	Pebibyte
+	Exbibyte
+)
+
+// Common decimal quantities of Bytes
+const (
+	Kilobyte Bytes = 1000
+	Megabyte       = 1000 * Kilobyte
+	Gigabyte       = 1000 * Megabyte
+	Terabyte       = 1000 * Gigabyte
+	Petabyte       = 1000 * Terabyte
+	Exabyte        = 1000 * Petabyte
+)
+
+// Bytes returns the exact number of Bytes measured by one of the symbol, in
+// the standard found by FindStandardBySymbol, i.e. 4 * bitty.GiB.Bytes() is
+// 4 * bitty.Gibibyte. It returns 0 if the symbol is not supported, does not
+// measure a whole number of bytes (like Bit), or overflows Bytes
+func (s UnitSymbol) Bytes() Bytes {
+	std, ok := FindStandardBySymbol(s)
+	if !ok {
+		return 0
+	}
+	r, ok := unitSymbolByteRat(std, s)
+	if !ok || !r.IsInt() {
+		return 0
+	}
+	b, err := ratToBytes(r)
+	if err != nil {
+		return 0
+	}
+	return b
+}
+
+var (
+	maxBytesRat = new(big.Rat).SetInt64(math.MaxInt64)
+	minBytesRat = new(big.Rat).SetInt64(math.MinInt64)
+)
+
+// UnitToBytes takes a Unit and returns the exact number of Bytes it holds,
+// rounded to the nearest byte, or an error if the Unit symbol is not
+// supported or the size overflows Bytes
+func UnitToBytes(u Unit) (Bytes, error) {
+	r, ok := unitByteRat(u)
+	if !ok {
+		if !ValidateSymbol(u.Symbol()) {
+			return 0, NewErrUnitSymbolNotSupported(u.Symbol())
+		}
+		return 0, ErrUnitOverflow
+	}
+	return ratToBytes(r)
+}
+
+// ratToBytes rounds a rational number of bytes half away from zero, returning
+// an error if it overflows Bytes
+func ratToBytes(r *big.Rat) (Bytes, error) {
+	half := big.NewRat(1, 2)
+	if r.Sign() < 0 {
+		half.Neg(half)
+	}
+	rounded := new(big.Rat).Add(r, half)
+	if rounded.Cmp(maxBytesRat) > 0 || rounded.Cmp(minBytesRat) < 0 {
+		return 0, ErrUnitOverflow
+	}
+	// Quo truncates towards zero, completing the rounding
+	n := new(big.Int).Quo(rounded.Num(), rounded.Denom())
+	if !n.IsInt64() {
+		return 0, ErrUnitOverflow
+	}
+	return Bytes(n.Int64()), nil
+}
+
+// ToUnit returns the Bytes as a Unit of the given standard, measured by the
+// greatest byte symbol which keeps the size at or above 1
+func (b Bytes) ToUnit(std UnitStandard) (Unit, error) {
+	size := new(big.Rat).SetInt64(int64(b))
+	sym, r, ok := bestUnitSymbol(std, size, false, big.NewRat(1, 1))
+	if !ok {
+		return nil, NewErrUnitStandardNotSupported(std)
+	}
+	f, _ := size.Quo(size, r).Float64()
+	return NewUnit(std, f, sym)
+}
+
+// Add returns the sum of two Bytes, or an error if it overflows
+func (b Bytes) Add(o Bytes) (Bytes, error) {
+	s := b + o
+	if (o > 0 && s < b) || (o < 0 && s > b) {
+		return 0, ErrUnitOverflow
+	}
+	return s, nil
+}
+
+// Sub returns the difference of two Bytes, or an error if it overflows
+func (b Bytes) Sub(o Bytes) (Bytes, error) {
+	d := b - o
+	if (o > 0 && d > b) || (o < 0 && d < b) {
+		return 0, ErrUnitOverflow
+	}
+	return d, nil
+}
+
+// Mul returns the Bytes multiplied by n, or an error if it overflows
+func (b Bytes) Mul(n int64) (Bytes, error) {
+	if b == 0 || n == 0 {
+		return 0, nil
+	}
+	p := b * Bytes(n)
+	if p/Bytes(n) != b || (b == -1 && n == math.MinInt64) || (n == -1 && b == math.MinInt64) {
+		return 0, ErrUnitOverflow
+	}
+	return p, nil
+}
+
+// Div returns the Bytes divided by n and truncated towards zero, or an error
+// if n is zero or the quotient overflows
+func (b Bytes) Div(n int64) (Bytes, error) {
+	if n == 0 {
+		return 0, ErrUnitDivideByZero
+	}
+	if n == -1 && b == math.MinInt64 {
+		return 0, ErrUnitOverflow
+	}
+	return b / Bytes(n), nil
+}
+
+// String returns the Bytes formatted by the greatest IEC byte symbol which
+// keeps the size at or above 1, i.e. "1.5 GiB"
+func (b Bytes) String() string {
+	u, err := b.ToUnit(IEC)
+	if err != nil {
+		return strconv.FormatInt(int64(b), 10) + " " + string(Byte)
+	}
+	return strconv.FormatFloat(u.Size(), 'f', -1, 64) + " " + string(u.Symbol())
+}
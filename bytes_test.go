@@ -0,0 +1,180 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleBytes() {
+	b := 4 * Gibibyte
+	fmt.Println(int64(b / Mebibyte))
+	fmt.Println(b)
+	fmt.Println(Exbibyte)
+	// Output:
+	// 4096
+	// 4 GiB
+	// 1 EiB
+}
+
+func ExampleUnitSymbol_Bytes() {
+	b := 4 * GiB.Bytes()
+	fmt.Println(b == 4*Gibibyte, int64(b))
+	fmt.Println(int64(100 * MB.Bytes()))
+	// Output:
+	// true 4294967296
+	// 100000000
+}
+
+func TestUnitSymbol_Bytes(t *testing.T) {
+	tt := []struct {
+		sym      UnitSymbol
+		expected Bytes
+	}{
+		{Byte, 1},
+		{KiB, Kibibyte},
+		{MiB, Mebibyte},
+		{GiB, Gibibyte},
+		{TiB, Tebibyte},
+		{PiB, Pebibyte},
+		{EiB, Exbibyte},
+		{kB, Kilobyte},
+		{MB, Megabyte},
+		{GB, Gigabyte},
+		{TB, Terabyte},
+		{PB, Petabyte},
+		{EB, Exabyte},
+		{KB, Kibibyte},
+		{Kib, 128},
+		{Bit, 0},
+		{ZiB, 0},
+		{UnitSymbol("FooBar"), 0},
+	}
+	for _, test := range tt {
+		assert.Equal(t, test.expected, test.sym.Bytes(), string(test.sym))
+	}
+}
+
+func ExampleBytes_ToUnit() {
+	b := 1536 * Mebibyte
+	iec, _ := b.ToUnit(IEC)
+	si, _ := b.ToUnit(SI)
+	fmt.Println(iec.Size(), iec.Symbol())
+	fmt.Println(si.Size(), si.Symbol())
+	// Output:
+	// 1.5 GiB
+	// 1.610612736 GB
+}
+
+func ExampleUnitToBytes() {
+	u, _ := NewIECUnit(1, EiB)
+	b, _ := UnitToBytes(u)
+	b, _ = b.Sub(1)
+	fmt.Println(int64(b))
+	// Output:
+	// 1152921504606846975
+}
+
+type testUnitToBytes struct {
+	unit     Unit
+	expected Bytes
+	err      error
+}
+
+func TestUnitToBytes(t *testing.T) {
+	tt := []testUnitToBytes{
+		{&IECUnit{4, GiB, 3}, 4 * Gibibyte, nil},
+		{&IECUnit{7, EiB, 6}, 7 * Exbibyte, nil},
+		{&IECUnit{8, EiB, 6}, 0, ErrUnitOverflow},
+		{&IECUnit{1, Kib, 1}, 128, nil},
+		{&IECUnit{12, Bit, 0}, 2, nil},
+		{&IECUnit{-1.5, KiB, 1}, -1536, nil},
+		{&SIUnit{9, EB, 18}, 9 * Exabyte, nil},
+		{&SIUnit{1.5, kB, 3}, 1500, nil},
+		{&SIUnit{math.NaN(), kB, 3}, 0, ErrUnitOverflow},
+		{&SIUnit{1, UnitSymbol("FooBar"), 30}, 0, NewErrUnitSymbolNotSupported("FooBar")},
+	}
+	for _, test := range tt {
+		b, err := UnitToBytes(test.unit)
+		if test.err != nil {
+			assert.EqualError(t, err, test.err.Error())
+		} else {
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, test.expected, b)
+	}
+}
+
+func TestBytes_ToUnit(t *testing.T) {
+	tt := []struct {
+		bytes    Bytes
+		std      UnitStandard
+		expected Unit
+	}{
+		{0, IEC, &IECUnit{0, Byte, 0}},
+		{1023, IEC, &IECUnit{1023, Byte, 0}},
+		{Kibibyte, IEC, &IECUnit{1, KiB, 1}},
+		{-3 * Tebibyte, IEC, &IECUnit{-3, TiB, 4}},
+		{2500 * Megabyte, SI, &SIUnit{2.5, GB, 9}},
+		{999, SI, &SIUnit{9.99, hB, 2}},
+	}
+	for _, test := range tt {
+		u, err := test.bytes.ToUnit(test.std)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, u)
+	}
+	_, err := Bytes(1).ToUnit(UnitStandard(50))
+	assert.Error(t, err)
+}
+
+func TestBytes_Arithmetic(t *testing.T) {
+	b, err := Exbibyte.Add(Exbibyte)
+	assert.NoError(t, err)
+	assert.Equal(t, 2*Exbibyte, b)
+	_, err = Bytes(math.MaxInt64).Add(1)
+	assert.Equal(t, ErrUnitOverflow, err)
+	_, err = Bytes(math.MinInt64).Add(-1)
+	assert.Equal(t, ErrUnitOverflow, err)
+
+	b, err = Gibibyte.Sub(Mebibyte)
+	assert.NoError(t, err)
+	assert.Equal(t, 1023*Mebibyte, b)
+	_, err = Bytes(math.MinInt64).Sub(1)
+	assert.Equal(t, ErrUnitOverflow, err)
+	_, err = Bytes(math.MaxInt64).Sub(-1)
+	assert.Equal(t, ErrUnitOverflow, err)
+
+	b, err = Gibibyte.Mul(3)
+	assert.NoError(t, err)
+	assert.Equal(t, 3*Gibibyte, b)
+	_, err = Exbibyte.Mul(8)
+	assert.Equal(t, ErrUnitOverflow, err)
+	_, err = Bytes(math.MinInt64).Mul(-1)
+	assert.Equal(t, ErrUnitOverflow, err)
+
+	b, err = Gibibyte.Div(4)
+	assert.NoError(t, err)
+	assert.Equal(t, 256*Mebibyte, b)
+	_, err = Gibibyte.Div(0)
+	assert.Equal(t, ErrUnitDivideByZero, err)
+	_, err = Bytes(math.MinInt64).Div(-1)
+	assert.Equal(t, ErrUnitOverflow, err)
+}
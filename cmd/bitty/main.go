@@ -0,0 +1,310 @@
+// Command bitty converts, normalizes and compares units of digital information
+// from the command line, i.e.
+//
+//	bitty convert 1.5GiB --to MB
+//	bitty normalize 1536MiB
+//	bitty compare 1GiB 1GB
+//	bitty std 1GiB --to SI
+//
+// Units are parsed as by bitty.Parse, either as one argument ("1.5GiB") or as
+// a size and a symbol ("1.5 GiB"). Flags may be given before or after the
+// arguments: --precision sets the number of decimals of sizes, --long prints
+// the long names of symbols, and --json prints the results as JSON objects.
+package main
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/the-forges/bitty"
+)
+
+// Exit codes
+const (
+	exitOK = iota
+	exitError
+	exitUsage
+)
+
+const usage = `Usage: bitty <command> [flags] <unit>...
+
+Commands:
+  convert <unit> --to <symbol>      convert a unit to a symbol, i.e. MB
+  normalize <unit>                  measure a unit by its most readable symbol
+  compare <unit> <unit>             compare the sizes of two units
+  std <unit> --to <standard>        convert a unit to a standard, i.e. SI
+
+Flags:
+`
+
+// options holds the flags shared by the commands
+type options struct {
+	to        string
+	precision int
+	long      bool
+	json      bool
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command given by args, writing its results to stdout and its
+// errors to stderr, and returns the exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	o := &options{}
+	fs := newFlagSet(o, stderr)
+	if len(args) == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	cmd := args[0]
+	if cmd == "help" || cmd == "-h" || cmd == "--help" {
+		newFlagSet(o, stdout).Usage()
+		return exitOK
+	}
+	if err := fs.Parse(reorderArgs(fs, args[1:])); err != nil {
+		return exitUsage
+	}
+	units, err := parseUnits(fs.Args())
+	if err != nil {
+		fmt.Fprintln(stderr, "bitty:", err)
+		return exitError
+	}
+	var out interface{}
+	switch cmd {
+	case "convert":
+		out, err = convert(units, o)
+	case "normalize":
+		out, err = normalize(units, o)
+	case "compare":
+		out, err = compare(units, o)
+	case "std":
+		out, err = std(units, o)
+	default:
+		fmt.Fprintf(stderr, "bitty: unknown command %q\n", cmd)
+		fs.Usage()
+		return exitUsage
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "bitty:", err)
+		return exitError
+	}
+	if o.json {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(out); err != nil {
+			fmt.Fprintln(stderr, "bitty:", err)
+			return exitError
+		}
+		return exitOK
+	}
+	fmt.Fprintln(stdout, out)
+	return exitOK
+}
+
+// newFlagSet returns the flags of the commands, set in o, with the usage and
+// errors written to w
+func newFlagSet(o *options, w io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("bitty", flag.ContinueOnError)
+	fs.SetOutput(w)
+	fs.StringVar(&o.to, "to", "", "the `symbol` (convert) or standard (std) to convert to")
+	fs.IntVar(&o.precision, "precision", -1, "the number of `decimals` of sizes, or -1 for as many as needed")
+	fs.BoolVar(&o.long, "long", false, "print the long names of symbols, i.e. gibibytes")
+	fs.BoolVar(&o.json, "json", false, "print the results as JSON")
+	fs.Usage = func() {
+		fmt.Fprint(w, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// reorderArgs moves the flags of args before the positional arguments, so
+// that flags may follow them as in "convert 1GiB --to MB". Arguments after
+// "--" are kept positional, as are negative sizes like "-1GiB"
+func reorderArgs(fs *flag.FlagSet, args []string) []string {
+	var flags, positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if !isFlag(arg) {
+			positional = append(positional, arg)
+			continue
+		}
+		flags = append(flags, arg)
+		name := strings.TrimLeft(arg, "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+		// Flags which are not boolean take the next argument as their value
+		if f := fs.Lookup(name); f != nil && !isBoolFlag(f) && i+1 < len(args) {
+			i++
+			flags = append(flags, args[i])
+		}
+	}
+	// "--" keeps positional arguments like "-1GiB" from being read as flags
+	return append(append(flags, "--"), positional...)
+}
+
+// isFlag reports whether an argument is a flag rather than a negative size
+func isFlag(arg string) bool {
+	name := strings.TrimLeft(arg, "-")
+	return len(arg) > len(name) && name != "" && (name[0] < '0' || name[0] > '9') && name[0] != '.'
+}
+
+// isBoolFlag reports whether a flag takes no value
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// parseUnits parses the units of the positional arguments, joining sizes
+// given apart from their symbols, i.e. "1.5" "GiB"
+func parseUnits(args []string) ([]bitty.Unit, error) {
+	var units []bitty.Unit
+	for i := 0; i < len(args); i++ {
+		s := args[i]
+		if _, err := strconv.ParseFloat(s, 64); err == nil && i+1 < len(args) {
+			i++
+			s += " " + args[i]
+		}
+		u, err := bitty.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		units = append(units, u)
+	}
+	return units, nil
+}
+
+// unitOutput is the output of a Unit, printed as its text or as JSON
+type unitOutput struct {
+	Size     float64 `json:"size"`
+	Symbol   string  `json:"symbol"`
+	Standard string  `json:"standard"`
+	Text     string  `json:"text"`
+}
+
+// newUnitOutput returns the output of a Unit formatted by the options. Symbols
+// which bitty.Parse would measure in another standard are followed by the
+// standard of the Unit, i.e. "1 GB (JEDEC)"
+func newUnitOutput(u bitty.Unit, o *options) unitOutput {
+	verb := "%"
+	if o.long {
+		verb += "+"
+	}
+	if o.precision >= 0 {
+		verb += "." + strconv.Itoa(o.precision)
+	}
+	text := fmt.Sprintf(verb+"v", u)
+	if std, ok := bitty.FindStandardBySymbol(u.Symbol()); ok && std != u.Standard() {
+		text += " (" + u.Standard().String() + ")"
+	}
+	return unitOutput{u.Size(), string(u.Symbol()), u.Standard().String(), text}
+}
+
+// String returns the text of a unitOutput
+func (u unitOutput) String() string {
+	return u.Text
+}
+
+// compareOutput is the output of the compare command
+type compareOutput struct {
+	Left       unitOutput `json:"left"`
+	Right      unitOutput `json:"right"`
+	Comparison int        `json:"comparison"`
+	Text       string     `json:"text"`
+}
+
+// String returns the text of a compareOutput
+func (c compareOutput) String() string {
+	return c.Text
+}
+
+// expectUnits returns an error unless n units are given
+func expectUnits(units []bitty.Unit, n int) error {
+	if len(units) != n {
+		return fmt.Errorf("expected %d unit(s), got %d", n, len(units))
+	}
+	return nil
+}
+
+// convert converts a unit to the symbol of --to
+func convert(units []bitty.Unit, o *options) (interface{}, error) {
+	if err := expectUnits(units, 1); err != nil {
+		return nil, err
+	}
+	if o.to == "" {
+		return nil, fmt.Errorf("convert requires --to <symbol>")
+	}
+	u, err := bitty.ConvertTo(units[0], bitty.UnitSymbol(o.to))
+	if err != nil {
+		return nil, err
+	}
+	return newUnitOutput(u, o), nil
+}
+
+// normalize measures a unit by its most readable symbol
+func normalize(units []bitty.Unit, o *options) (interface{}, error) {
+	if err := expectUnits(units, 1); err != nil {
+		return nil, err
+	}
+	return newUnitOutput(bitty.Normalize(units[0]), o), nil
+}
+
+// compare compares the sizes of two units
+func compare(units []bitty.Unit, o *options) (interface{}, error) {
+	if err := expectUnits(units, 2); err != nil {
+		return nil, err
+	}
+	l, r := newUnitOutput(units[0], o), newUnitOutput(units[1], o)
+	c := bitty.Compare(units[0], units[1])
+	op := map[int]string{-1: "<", 0: "=", 1: ">"}[c]
+	return compareOutput{l, r, c, l.Text + " " + op + " " + r.Text}, nil
+}
+
+// std converts a unit to the standard of --to
+func std(units []bitty.Unit, o *options) (interface{}, error) {
+	if err := expectUnits(units, 1); err != nil {
+		return nil, err
+	}
+	if o.to == "" {
+		return nil, fmt.Errorf("std requires --to <standard>")
+	}
+	s, err := bitty.ParseUnitStandard(o.to)
+	if err != nil {
+		if s, err = bitty.ParseUnitStandard(strings.ToUpper(o.to)); err != nil {
+			return nil, err
+		}
+	}
+	u, err := bitty.ConvertUnitStd(units[0], s)
+	if err != nil {
+		return nil, err
+	}
+	return newUnitOutput(u, o), nil
+}
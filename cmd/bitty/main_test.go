@@ -0,0 +1,118 @@
+package main
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testRun struct {
+	args   []string
+	code   int
+	stdout string
+	stderr string
+}
+
+func TestRun(t *testing.T) {
+	tt := []testRun{
+		{[]string{"convert", "1.5GiB", "--to", "MB"}, exitOK, "1610.612736 MB\n", ""},
+		{[]string{"convert", "--to", "MB", "1.5", "GiB"}, exitOK, "1610.612736 MB\n", ""},
+		{[]string{"convert", "1.5GiB", "--to=MB", "--precision", "1"}, exitOK, "1610.6 MB\n", ""},
+		{[]string{"convert", "1 GiB", "--to", "Gib", "--long"}, exitOK, "8 gibibits\n", ""},
+		{[]string{"convert", "-1GiB", "--to", "MiB"}, exitOK, "-1024 MiB\n", ""},
+		{[]string{"normalize", "1536MiB"}, exitOK, "1.5 GiB\n", ""},
+		{[]string{"normalize", "0.5", "MiB", "--long"}, exitOK, "512 kibibytes\n", ""},
+		{[]string{"compare", "1GiB", "1GB"}, exitOK, "1 GiB > 1 GB\n", ""},
+		{[]string{"compare", "1000", "MB", "1", "GB"}, exitOK, "1000 MB = 1 GB\n", ""},
+		{[]string{"compare", "1KiB", "1KB", "--long"}, exitOK, "1 kibibyte = 1 kilobyte\n", ""},
+		{[]string{"compare", "1MB", "1MiB"}, exitOK, "1 MB < 1 MiB\n", ""},
+		{[]string{"compare", "1.1GB", "1100MB"}, exitOK, "1.1 GB = 1100 MB\n", ""},
+		{[]string{"compare", "0.3kB", "300Byte"}, exitOK, "0.3 kB = 300 Byte\n", ""},
+		{[]string{"std", "1GiB", "--to", "SI"}, exitOK, "1.073741824 GB\n", ""},
+		{[]string{"std", "1GiB", "--to", "si", "--precision", "2"}, exitOK, "1.07 GB\n", ""},
+		{[]string{"std", "--to", "JEDEC", "1 GB"}, exitOK, "953.67431640625 MB (JEDEC)\n", ""},
+		{[]string{"std", "1GiB", "--to", "JEDEC"}, exitOK, "1 GB (JEDEC)\n", ""},
+		{[]string{"std", "16 GB (JEDEC)", "--to", "IEC"}, exitOK, "16 GiB\n", ""},
+		{[]string{"convert", "1GiB", "--to", "Byte"}, exitOK, "1073741824 Byte (IEC)\n", ""},
+		// Errors
+		{[]string{"convert", "1 FooBar", "--to", "MB"}, exitError, "", "bitty: parsing \"1 FooBar\": invalid symbol at offset 2: unit symbol not supported\n"},
+		{[]string{"convert", "1GiB"}, exitError, "", "bitty: convert requires --to <symbol>\n"},
+		{[]string{"convert", "1GiB", "--to", "FooBar"}, exitError, "", "bitty: unit symbol not supported: FooBar\n"},
+		{[]string{"compare", "1GiB"}, exitError, "", "bitty: expected 2 unit(s), got 1\n"},
+		{[]string{"std", "1GiB", "--to", "ISO"}, exitError, "", "bitty: parsing \"ISO\": invalid standard at offset 0: unit standard not supported\n"},
+	}
+	for _, test := range tt {
+		var stdout, stderr bytes.Buffer
+		code := run(test.args, &stdout, &stderr)
+		assert.Equal(t, test.code, code, "%q", test.args)
+		assert.Equal(t, test.stdout, stdout.String(), "%q", test.args)
+		assert.Equal(t, test.stderr, stderr.String(), "%q", test.args)
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	for _, args := range [][]string{nil, {"frobnicate", "1GiB"}, {"convert", "--frobnicate"}} {
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, exitUsage, run(args, &stdout, &stderr), "%q", args)
+		assert.Empty(t, stdout.String(), "%q", args)
+		assert.Contains(t, stderr.String(), "Usage: bitty", "%q", args)
+	}
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, exitOK, run([]string{"help"}, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "Usage: bitty")
+	assert.Empty(t, stderr.String())
+}
+
+func TestRun_JSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, exitOK, run([]string{"convert", "1.5GiB", "--to", "MB", "--json", "--precision", "2"}, &stdout, &stderr))
+	var u unitOutput
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &u))
+	assert.Equal(t, unitOutput{1610.612736, "MB", "SI", "1610.61 MB"}, u)
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"compare", "--json", "1GiB", "1GB"}, &stdout, &stderr))
+	var c compareOutput
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &c))
+	assert.Equal(t, 1, c.Comparison)
+	assert.Equal(t, "1 GiB > 1 GB", c.Text)
+	assert.Equal(t, unitOutput{1, "GiB", "IEC", "1 GiB"}, c.Left)
+	assert.Contains(t, stdout.String(), `"text": "1 GiB > 1 GB"`)
+	assert.Empty(t, stderr.String())
+}
+
+func TestReorderArgs(t *testing.T) {
+	tt := []struct {
+		in       []string
+		expected []string
+	}{
+		{[]string{"1GiB", "--to", "MB"}, []string{"--to", "MB", "--", "1GiB"}},
+		{[]string{"1GiB", "--json", "2GiB"}, []string{"--json", "--", "1GiB", "2GiB"}},
+		{[]string{"1", "GiB", "--to=MB", "--long"}, []string{"--to=MB", "--long", "--", "1", "GiB"}},
+		{[]string{"-1GiB", "-.5", "GiB"}, []string{"--", "-1GiB", "-.5", "GiB"}},
+		{[]string{"--to", "MB", "--", "--json"}, []string{"--to", "MB", "--", "--json"}},
+	}
+	fs := newFlagSet(&options{}, ioutil.Discard)
+	for _, test := range tt {
+		assert.Equal(t, test.expected, reorderArgs(fs, test.in), "%q", test.in)
+	}
+}
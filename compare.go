@@ -0,0 +1,95 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"math/big"
+	"sort"
+)
+
+// byteRat returns the exact number of bytes held by a Unit, falling back to
+// its ByteSize when the symbol is not supported
+func byteRat(u Unit) *big.Rat {
+	if r, ok := unitByteRat(u); ok {
+		return r
+	}
+	if r := new(big.Rat).SetFloat64(u.ByteSize()); r != nil {
+		return r
+	}
+	return new(big.Rat)
+}
+
+// Compare compares the sizes of two Units of any standard by the exact number
+// of bytes they hold, returning -1 if a is less than b, 0 if a is equal to b,
+// and +1 if a is greater than b. The size of a float64 backed Unit is taken as
+// its shortest decimal form, so that 1.1 GB is equal to 1100 MB
+func Compare(a, b Unit) int {
+	return byteRat(a).Cmp(byteRat(b))
+}
+
+// Equal reports whether two Units of any standard hold the same number of
+// bytes, as by Compare. If a tolerance Unit is given, Equal reports whether the
+// difference between them is no greater than the size of the tolerance
+func Equal(a, b Unit, tolerance ...Unit) bool {
+	if len(tolerance) == 0 {
+		return Compare(a, b) == 0
+	}
+	diff := new(big.Rat).Sub(byteRat(a), byteRat(b))
+	tol := byteRat(tolerance[0])
+	return diff.Abs(diff).Cmp(tol.Abs(tol)) <= 0
+}
+
+// Less reports whether Unit a holds fewer bytes than Unit b
+func Less(a, b Unit) bool {
+	return Compare(a, b) < 0
+}
+
+// Min returns the Unit holding the fewest bytes, the first of them if several
+// are equal, or nil if no Units are given
+func Min(units ...Unit) Unit {
+	var min Unit
+	for _, u := range units {
+		if min == nil || Less(u, min) {
+			min = u
+		}
+	}
+	return min
+}
+
+// Max returns the Unit holding the most bytes, the first of them if several
+// are equal, or nil if no Units are given
+func Max(units ...Unit) Unit {
+	var max Unit
+	for _, u := range units {
+		if max == nil || Less(max, u) {
+			max = u
+		}
+	}
+	return max
+}
+
+// ByteSizeSlice attaches the methods of sort.Interface to []Unit, sorting in
+// increasing order of the bytes held by each Unit regardless of standard
+type ByteSizeSlice []Unit
+
+func (s ByteSizeSlice) Len() int           { return len(s) }
+func (s ByteSizeSlice) Less(i, j int) bool { return Less(s[i], s[j]) }
+func (s ByteSizeSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// Sort is a convenience method: s.Sort() calls sort.Stable(s), keeping equal
+// Units in their original order
+func (s ByteSizeSlice) Sort() { sort.Stable(s) }
@@ -0,0 +1,143 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleCompare() {
+	a, _ := Parse("1 GiB")
+	b, _ := Parse("1 GB")
+	fmt.Println(Compare(a, b), Compare(b, a), Compare(a, a))
+	// Output:
+	// 1 -1 0
+}
+
+func ExampleByteSizeSlice() {
+	units := make(ByteSizeSlice, 0, 4)
+	for _, s := range []string{"1 GiB", "1 GB", "900 MiB", "8000 Mb"} {
+		u, _ := Parse(s)
+		units = append(units, u)
+	}
+	sort.Sort(units)
+	for _, u := range units {
+		fmt.Println(u.Size(), u.Symbol())
+	}
+	// Output:
+	// 900 MiB
+	// 1 GB
+	// 8000 Mb
+	// 1 GiB
+}
+
+type testCompare struct {
+	l, r     string
+	expected int
+}
+
+func TestCompare(t *testing.T) {
+	tt := []testCompare{
+		{"1 GiB", "1024 MiB", 0},
+		{"1 GiB", "1073741824 Byte", 0},
+		{"1 GB", "1000 MB", 0},
+		{"1 GB", "8 Gb", 0},
+		{"1 KiB", "1 kB", 1},
+		{"1 kB", "1 KiB", -1},
+		{"-1 TB", "0 Byte", -1},
+		{"1.1 GB", "1100 MB", 0},
+		{"0.3 kB", "300 Byte", 0},
+		{"0.3 kB", "301 Byte", -1},
+		{"1.1 ZB", "1100 EB", 0},
+		{"1 TB", "1000000000001 Byte", -1},
+		{"1000000000001 Byte", "1 TB", 1},
+	}
+	for _, test := range tt {
+		l, err := Parse(test.l)
+		assert.NoError(t, err)
+		r, err := Parse(test.r)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, Compare(l, r), "%s <=> %s", test.l, test.r)
+		assert.Equal(t, test.expected == 0, Equal(l, r))
+		assert.Equal(t, test.expected < 0, Less(l, r))
+	}
+}
+
+func TestCompare_BigUnit(t *testing.T) {
+	a, _ := NewBigUnit(IEC, big.NewRat(1, 1), YiB)
+	b := a.Add(&IECUnit{1, Byte, 0})
+	c := &IECUnit{1, YiB, 8}
+	assert.Equal(t, -1, Compare(a, b))
+	assert.Equal(t, 1, Compare(b, a))
+	assert.Equal(t, 0, Compare(a, c))
+	assert.Equal(t, 1, Compare(b, c))
+	assert.Equal(t, -1, Compare(c, b))
+	// 1 EiB - 1 Byte is exact as a *BigUnit, but not as an IECUnit
+	d, _ := NewBigUnit(IEC, big.NewRat(1152921504606846975, 1), Byte)
+	e := &IECUnit{1, EiB, 6}
+	assert.Equal(t, -1, Compare(d, e))
+	assert.Equal(t, e, Max(d, e))
+	assert.False(t, Equal(d, e))
+	assert.True(t, Equal(d, e, &IECUnit{1, Byte, 0}))
+}
+
+func TestEqual(t *testing.T) {
+	a := &SIUnit{1.073741823, GB, 9}
+	b := &IECUnit{1, GiB, 3}
+	assert.True(t, Equal(&SIUnit{1.073741824, GB, 9}, b), "decimal sizes are exact")
+	assert.False(t, Equal(a, b))
+	assert.True(t, Equal(a, b, &SIUnit{1, Byte, 0}))
+	assert.True(t, Equal(b, a, &SIUnit{-1, Byte, 0}))
+	assert.False(t, Equal(a, b, &SIUnit{0.5, Byte, 0}))
+	assert.False(t, Equal(&SIUnit{1, GB, 9}, b, &IECUnit{1, MiB, 2}))
+	assert.True(t, Equal(&SIUnit{1, GB, 9}, b, &IECUnit{100, MiB, 2}))
+}
+
+func TestMinMax(t *testing.T) {
+	a := &IECUnit{1, GiB, 3}
+	b := &SIUnit{1, GB, 9}
+	c := &SIUnit{1000, MB, 6}
+	d := &IECUnit{1, KiB, 1}
+	assert.Nil(t, Min())
+	assert.Nil(t, Max())
+	assert.Equal(t, d, Min(a, b, c, d))
+	assert.Equal(t, a, Max(a, b, c, d))
+	assert.Equal(t, b, Min(b, c))
+	assert.Equal(t, c, Min(c, b))
+	assert.Equal(t, b, Max(b, c))
+	tb := &SIUnit{1, TB, 12}
+	more := &SIUnit{1000000000001, Byte, 0}
+	assert.Equal(t, more, Max(tb, more))
+	assert.Equal(t, more, Max(more, tb))
+	assert.True(t, Less(tb, more))
+	assert.False(t, Less(more, tb))
+}
+
+func TestByteSizeSlice_Sort(t *testing.T) {
+	a := &IECUnit{1, GiB, 3}
+	b := &SIUnit{1, GB, 9}
+	c := &SIUnit{1000, MB, 6}
+	d := &IECUnit{1, KiB, 1}
+	s := ByteSizeSlice{a, b, c, d}
+	s.Sort()
+	assert.Equal(t, ByteSizeSlice{d, b, c, a}, s)
+}
@@ -17,228 +17,414 @@ package bitty
 */
 
 import (
-	"fmt"
 	"math"
+	"math/big"
 	"regexp"
 	"strconv"
+	"strings"
 )
 
-// NewUnit takes a UnitStandard, float64, and UnitSymbol, returning a valid Unit
+// NewUnit takes a UnitStandard, float64, and UnitSymbol, returning a valid Unit.
+// Units of standards registered with RegisterStandard are returned as a
+// *BigUnit
 func NewUnit(std UnitStandard, size float64, sym UnitSymbol) (Unit, error) {
 	switch std {
 	case IEC:
 		return NewIECUnit(size, sym)
 	case SI:
 		return NewSIUnit(size, sym)
-	default:
-		return nil, fmt.Errorf("%v is currently not a supported standard", std)
+	case JEDEC:
+		return NewJEDECUnit(size, sym)
+	}
+	if _, ok := DefaultRegistry.StandardName(std); ok {
+		r := new(big.Rat).SetFloat64(size)
+		if r == nil {
+			return nil, ErrUnitOverflow
+		}
+		return NewBigUnit(std, r, sym)
 	}
+	return nil, NewErrUnitStandardNotSupported(std)
 }
 
 // FindUnitSymbolPairBySymbol takes a UnitStandard and a symbol in order to
 // find and return the UnitSymbolPair for that standard and symbol, or false
-// if the UnitSymbolPair cannot be found.
+// if the UnitSymbolPair cannot be found in the DefaultRegistry.
 func FindUnitSymbolPairBySymbol(std UnitStandard, sym UnitSymbol) (UnitSymbolPair, bool) {
-	var stdMatch, symMatch bool
-	for _, p := range unitSymbolPairs {
-		stdMatch = p.Standard() == std
-		symMatch = (p.Least() == sym || p.Greatest() == sym)
-		if stdMatch && symMatch {
-			return p, true
-		}
-	}
-	return nil, false
+	return DefaultRegistry.FindUnitSymbolPairBySymbol(std, sym)
 }
 
 // FindUnitSymbolPairByExponent takes a UnitStandard and an exponent in order to
 // find and return the UnitSymbolPair for that standard and exponent, or false
-// if the UnitSymbolPair cannot be found.
+// if the UnitSymbolPair cannot be found in the DefaultRegistry.
 func FindUnitSymbolPairByExponent(std UnitStandard, exp int) (UnitSymbolPair, bool) {
-	var stdMatch, expMatch bool
-	for _, p := range unitSymbolPairs {
-		stdMatch = p.Standard() == std
-		expMatch = p.Exponent() == exp
-		if stdMatch && expMatch {
-			return p, true
-		}
-	}
-	return nil, false
+	return DefaultRegistry.FindUnitSymbolPairByExponent(std, exp)
 }
 
-// FindStandardBySymbol takes a unit symbol, searches for a symbol pair that
-// matches, and returns the standard for that pair
+// FindStandardBySymbol takes a unit symbol, searches the DefaultRegistry for a
+// symbol pair that matches, and returns the standard for that pair
 func FindStandardBySymbol(sym UnitSymbol) (UnitStandard, bool) {
-	for _, p := range unitSymbolPairs {
-		if p.Least() == sym || p.Greatest() == sym {
-			return p.Standard(), true
-		}
-	}
-	return UnitStandard(0), false
+	return DefaultRegistry.FindStandardBySymbol(sym)
 }
 
 // FindExponentBySymbol takes a symbol and returns the exponent
 func FindExponentBySymbol(sym UnitSymbol) (int, bool) {
-	s, ok := FindStandardBySymbol(sym)
+	return DefaultRegistry.FindExponentBySymbol(sym)
+}
+
+// FindGreatestUnitSymbol finds the greatest of two unit symbols for a given
+// exponent by standard, or returns Byte and false if no symbol pair has that
+// exponent. Use FindFloorUnitSymbolPair in order to find the symbol pair of
+// the closest lower exponent.
+func FindGreatestUnitSymbol(std UnitStandard, exp int) (UnitSymbol, bool) {
+	return DefaultRegistry.FindGreatestUnitSymbol(std, exp)
+}
+
+// FindLeastUnitSymbol finds the least of two unit symbols for a given
+// exponent by standard, or returns Bit and false if no symbol pair has that
+// exponent. Use FindFloorUnitSymbolPair in order to find the symbol pair of
+// the closest lower exponent.
+func FindLeastUnitSymbol(std UnitStandard, exp int) (UnitSymbol, bool) {
+	return DefaultRegistry.FindLeastUnitSymbol(std, exp)
+}
+
+// FindFloorUnitSymbolPair finds the UnitSymbolPair of a standard with the
+// greatest exponent less than or equal to a given exponent, i.e. kB for an SI
+// exponent of 4, or false if there is none. This supports base 10 decimal
+// values where the exponents do not flow smoothly (like base 2 does).
+func FindFloorUnitSymbolPair(std UnitStandard, exp int) (UnitSymbolPair, bool) {
+	return DefaultRegistry.FindFloorUnitSymbolPair(std, exp)
+}
+
+// unitSymbolByteRat returns the exact number of bytes held by one of a given
+// UnitSymbol for a standard, or false if the symbol is not supported
+func unitSymbolByteRat(std UnitStandard, sym UnitSymbol) (*big.Rat, bool) {
+	pair, ok := FindUnitSymbolPairBySymbol(std, sym)
 	if !ok {
-		return 0, false
+		return nil, false
 	}
-	pair, ok := FindUnitSymbolPairBySymbol(s, sym)
+	radix, step, ok := DefaultRegistry.radix(std)
 	if !ok {
-		return 0, false
+		return nil, false
+	}
+	pow := int64(pair.Exponent() * step)
+	neg := pow < 0
+	if neg {
+		pow = -pow
 	}
-	return pair.Exponent(), true
+	n := new(big.Int).Exp(big.NewInt(int64(radix)), big.NewInt(pow), nil)
+	r := new(big.Rat).SetInt(n)
+	if neg {
+		r.Inv(r)
+	}
+	if sym == pair.Least() {
+		r.Quo(r, big.NewRat(8, 1))
+	}
+	return r, true
 }
 
-// FindGreatestUnitSymbol finds the greatest of two unit symbols for a given
-// exponent by standard.
-//
-// Currently, if no symbol pair is found, we attempt to recursively find the
-// floor exponent in which one is found, or return Byte. This is mostly to
-// support base 10 decimal values where the exponents do not flow smoothly
-// from 1-8 (like base 2 does).
-func FindGreatestUnitSymbol(std UnitStandard, exp int) (UnitSymbol, bool) {
-	pair, ok := FindUnitSymbolPairByExponent(std, exp)
-	if !ok && exp <= 0 {
-		return Byte, false
-	} else if !ok {
-		return FindGreatestUnitSymbol(std, exp-1)
+// unitByteRat returns the exact number of bytes held by a Unit, or false if
+// the Unit symbol or size cannot be represented. The size of a float64 backed
+// Unit is taken as its shortest decimal form, so that 1.1 GB holds exactly
+// 1100000000 bytes rather than the binary approximation of 1.1, while whole
+// sizes are exact as they are
+func unitByteRat(u Unit) (*big.Rat, bool) {
+	if b, ok := u.(*BigUnit); ok {
+		return b.ByteRat(), true
+	}
+	r, ok := unitSymbolByteRat(u.Standard(), u.Symbol())
+	if !ok {
+		return nil, false
+	}
+	size := new(big.Rat)
+	if f := u.Size(); f == math.Trunc(f) {
+		if size.SetFloat64(f) == nil {
+			return nil, false
+		}
+	} else if _, ok := size.SetString(strconv.FormatFloat(f, 'g', -1, 64)); !ok {
+		return nil, false
 	}
-	return pair.Greatest(), true
+	return r.Mul(r, size), true
 }
 
-// FindLeastUnitSymbol finds the least of two unit symbols for a given
-// exponent by standard.
-//
-// Currently, if no symbol pair is found, we attempt to recursively find the
-// floor exponent in which one is found, or return Bit. This is mostly to
-// support base 10 decimal values where the exponents do not flow smoothly
-// from 1-8 (like base 2 does).
-func FindLeastUnitSymbol(std UnitStandard, exp int) (UnitSymbol, bool) {
-	pair, ok := FindUnitSymbolPairByExponent(std, exp)
-	if !ok && exp <= 0 {
-		return Bit, false
-	} else if !ok {
-		return FindLeastUnitSymbol(std, exp-1)
+// bestUnitSymbol finds the UnitSymbol of a standard with the greatest exponent
+// which keeps a number of bytes at or above a threshold when measured by it,
+// choosing between the bit or byte symbol of each pair. It returns the symbol
+// with the exact number of bytes it holds, or the symbol of exponent 0 if none
+// is found, or false if the standard is not supported
+func bestUnitSymbol(std UnitStandard, bytes *big.Rat, bits bool, threshold *big.Rat) (UnitSymbol, *big.Rat, bool) {
+	pick := func(p UnitSymbolPair) UnitSymbol {
+		if bits {
+			return p.Least()
+		}
+		return p.Greatest()
+	}
+	base, ok := FindUnitSymbolPairByExponent(std, 0)
+	if !ok {
+		return "", nil, false
+	}
+	sym := pick(base)
+	best, ok := unitSymbolByteRat(std, sym)
+	if !ok {
+		return "", nil, false
 	}
-	return pair.Least(), true
+	abs := new(big.Rat).Abs(bytes)
+	for _, p := range DefaultRegistry.Pairs() {
+		if p.Standard() != std || p.Exponent() <= base.Exponent() {
+			continue
+		}
+		r, ok := unitSymbolByteRat(std, pick(p))
+		if !ok || r.Cmp(best) <= 0 {
+			continue
+		}
+		if new(big.Rat).Mul(r, threshold).Cmp(abs) <= 0 {
+			sym, best = pick(p), r
+		}
+	}
+	return sym, best, true
+}
+
+// isBitUnitSymbol reports whether a symbol is the bit (least) symbol of its
+// UnitSymbolPair for a standard
+func isBitUnitSymbol(std UnitStandard, sym UnitSymbol) bool {
+	pair, ok := FindUnitSymbolPairBySymbol(std, sym)
+	return ok && pair.Least() == sym
 }
 
 // UnitSymbolToByteSize converts the size from one unit into bytes
 func UnitSymbolToByteSize(std UnitStandard, sym UnitSymbol, size float64) float64 {
-	var exp, bytes, errVal float64
-	pair, ok := FindUnitSymbolPairBySymbol(std, sym)
+	pair, factor, ok := unitSymbolFactor(std, sym)
 	if !ok {
-		return errVal
+		return float64(0)
 	}
-	switch std {
-	case IEC:
-		exp = float64(pair.Exponent() * 10)
-		bytes = float64(math.Exp2(exp) * size)
-	case SI:
-		exp = float64(pair.Exponent())
-		bytes = float64(math.Pow10(int(exp)) * size)
-	default:
-		return errVal
-	}
-	switch sym {
-	case Bit:
-		return float64(size * 8)
-	case Byte:
-		return float64(size)
-	case Kib, Mib, Gib, Tib, Pib, Eib, Zib, Yib:
-		return float64(bytes * 0.125)
-	case KiB, MiB, GiB, TiB, PiB, EiB, ZiB, YiB:
-		return float64(bytes)
-	case db, hb, kb, Mb, Gb, Tb, Pb, Eb, Zb, Yb:
-		return float64(bytes * 0.125)
-	case dB, hB, kB, MB, GB, TB, PB, EB, ZB, YB:
-		return float64(bytes)
-	default:
-		return errVal
+	if sym == pair.Least() {
+		return float64(factor * size * 0.125)
 	}
+	return float64(factor * size)
 }
 
 // BytesToUnitSymbolSize converts bytes to the best unit size as a float64
 func BytesToUnitSymbolSize(std UnitStandard, sym UnitSymbol, size float64) float64 {
-	var exp float64
-	pair, ok := FindUnitSymbolPairBySymbol(std, sym)
+	pair, factor, ok := unitSymbolFactor(std, sym)
 	if !ok {
 		return float64(0)
 	}
-	switch std {
-	case IEC:
-		exp = math.Pow(2, float64(pair.Exponent()*10))
-	case SI:
-		exp = math.Pow10(int(pair.Exponent()))
+	if sym == pair.Least() {
+		return size * 8 / factor
+	}
+	return size / factor
+}
+
+// unitSymbolFactor returns the UnitSymbolPair of a symbol for a standard with
+// the number of bytes measured by the greatest symbol of the pair as a float64
+func unitSymbolFactor(std UnitStandard, sym UnitSymbol) (UnitSymbolPair, float64, bool) {
+	pair, ok := FindUnitSymbolPairBySymbol(std, sym)
+	if !ok {
+		return nil, 0, false
+	}
+	radix, step, ok := DefaultRegistry.radix(std)
+	if !ok {
+		return nil, 0, false
+	}
+	exp := pair.Exponent() * step
+	switch radix {
+	case 2:
+		return pair, math.Exp2(float64(exp)), true
+	case 10:
+		return pair, math.Pow10(exp), true
+	default:
+		return pair, math.Pow(float64(radix), float64(exp)), true
+	}
+}
+
+// findStandardForSymbol finds the standard of a symbol, preferring a given
+// standard for symbols supported by several standards (like Bit and Byte)
+func findStandardForSymbol(prefer UnitStandard, sym UnitSymbol) (UnitStandard, bool) {
+	if _, ok := FindUnitSymbolPairBySymbol(prefer, sym); ok {
+		return prefer, true
+	}
+	return FindStandardBySymbol(sym)
+}
+
+// parseRegexp matches "<size><unit symbol>" or "<size> <unit symbol>"
+var parseRegexp = regexp.MustCompile(`^([-\d\.]+)\s{0,}(\w+)$`)
+
+// parseNumberRegexp matches the size at the start of "<size> <unit symbol>",
+// which locates the symbol of inputs not matched by parseRegexp
+var parseNumberRegexp = regexp.MustCompile(`^[-\d\.]+\s{0,}`)
+
+// parseSizeSymbol parses a string representation of a unit size in the format
+// of "<size><unit symbol>" or "<size> <unit symbol>" into its size and symbol,
+// along with the offset of the symbol in s. Errors are returned as *ParseError
+func parseSizeSymbol(s string) (float64, UnitSymbol, int, error) {
+	m := parseRegexp.FindStringSubmatchIndex(s)
+	if m == nil {
+		if n := parseNumberRegexp.FindStringIndex(s); n != nil {
+			return 0, "", 0, &ParseError{s, n[1], ComponentSymbol, ErrUnitSymbolNotSupported}
+		}
+		return 0, "", 0, &ParseError{s, 0, ComponentNumber, strconv.ErrSyntax}
+	}
+	size, err := strconv.ParseFloat(s[m[2]:m[3]], 64)
+	if err != nil {
+		return 0, "", 0, &ParseError{s, m[2], ComponentNumber, err.(*strconv.NumError).Err}
+	}
+	return size, UnitSymbol(s[m[4]:m[5]]), m[4], nil
+}
+
+// standardQualifierRegexp matches the standard which may follow the symbol of
+// "<size> <unit symbol> (<standard>)"
+var standardQualifierRegexp = regexp.MustCompile(`\s{0,}\((\w+)\)$`)
+
+// parseQualifiedSizeSymbol parses a string representation of a unit size like
+// parseSizeSymbol, which may be qualified by the standard measuring its
+// symbol, i.e. "16 GB (JEDEC)". The standard is returned along with whether
+// the string was qualified. Errors are returned as *ParseError
+func parseQualifiedSizeSymbol(s string) (float64, UnitSymbol, int, UnitStandard, bool, error) {
+	m := standardQualifierRegexp.FindStringSubmatchIndex(s)
+	if m == nil {
+		size, sym, off, err := parseSizeSymbol(s)
+		return size, sym, off, UnitStandard(0), false, err
+	}
+	size, sym, off, err := parseSizeSymbol(s[:m[0]])
+	if err != nil {
+		if pe, ok := err.(*ParseError); ok {
+			pe.Input = s
+		}
+		return 0, "", 0, UnitStandard(0), false, err
+	}
+	std, ok := DefaultRegistry.FindStandardByName(s[m[2]:m[3]])
+	if !ok {
+		return 0, "", 0, UnitStandard(0), false, &ParseError{s, m[2], ComponentStandard, ErrUnitStandardNotSupported}
+	}
+	if _, ok := FindUnitSymbolPairBySymbol(std, sym); !ok {
+		return 0, "", 0, UnitStandard(0), false, newSymbolParseError(s, off, sym)
+	}
+	return size, sym, off, std, true, nil
+}
+
+// formatQualifiedUnit formats a Unit like its String method, qualifying the
+// symbol by the standard of the Unit whenever Parse would measure the symbol
+// in another standard, i.e. "16 GB (JEDEC)" or "512 Byte (IEC)". A zero Unit
+// without a symbol is formatted as 0 Byte, so that it can be parsed again
+func formatQualifiedUnit(u Unit) string {
+	if u.Symbol() == "" && u.Size() == 0 {
+		if z, err := NewUnit(u.Standard(), 0, Byte); err == nil {
+			u = z
+		}
+	}
+	s, _ := formatUnit(u, 'v', 0, false, false)
+	if std, ok := FindStandardBySymbol(u.Symbol()); ok && std != u.Standard() {
+		s += " (" + u.Standard().String() + ")"
+	}
+	return s
+}
+
+// newSymbolParseError returns a *ParseError for a symbol at offset off of s
+// which is not supported by the expected standard. Symbols of other standards
+// are reported as unsupported standards, and unknown symbols as unsupported
+// symbols
+func newSymbolParseError(s string, off int, sym UnitSymbol) error {
+	if _, ok := FindStandardBySymbol(sym); ok {
+		return &ParseError{s, off, ComponentStandard, ErrUnitStandardNotSupported}
+	}
+	return &ParseError{s, off, ComponentSymbol, ErrUnitSymbolNotSupported}
+}
+
+// parseUnitWithin parses a string representation of a unit size like Parse,
+// with the symbol measured strictly in the given standard. A qualified
+// standard must be the given standard
+func parseUnitWithin(s string, std UnitStandard) (Unit, error) {
+	size, symbol, off, qstd, qualified, err := parseQualifiedSizeSymbol(s)
+	if err != nil {
+		return nil, err
+	}
+	if qualified && qstd != std {
+		return nil, &ParseError{s, strings.LastIndexByte(s, '(') + 1, ComponentStandard, ErrUnitStandardNotSupported}
 	}
-	switch sym {
-	case Bit:
-		return float64(size * 8)
-	case Byte:
-		return size / exp
-	case db, hb, kb, Mb, Gb, Tb, Pb, Eb, Zb, Yb,
-		dB, hB, kB, MB, GB, TB, PB, EB, ZB, YB:
-		return size / exp
-	case Kib, Mib, Gib, Tib, Pib, Eib, Zib, Yib:
-		return (size / (size * 0.125)) / 2
-	case KiB, MiB, GiB, TiB, PiB, EiB, ZiB, YiB:
-		return size / exp
+	if _, ok := FindUnitSymbolPairBySymbol(std, symbol); !ok {
+		return nil, newSymbolParseError(s, off, symbol)
 	}
-	return float64(0)
+	return NewUnit(std, size, symbol)
 }
 
 // Parse parses a string representation of a unit size in the format of
 // "<size><unit symbol>" or "<size> <unit symbol>" in order to instantiate and
-// return a Unit with the correct standard, exponent, size, and symbol
+// return a Unit with the correct standard, exponent, size, and symbol. Symbols
+// supported by several standards are measured in the standard which first
+// registered them, unless qualified by a standard like "16 GB (JEDEC)"
 func Parse(s string) (Unit, error) {
-	stderr := ErrUnitStandardNotSupported
-	parseerr := NewErrUnitCouldNotBeParsed(s)
-	r := regexp.MustCompile(`^([-\d\.]+)\s{0,}(\w+)$`)
-	if r.MatchString(s) {
-		m := r.FindStringSubmatch(s)
-		if len(m) < 1 {
-			return nil, parseerr
-		}
-		size, err := strconv.ParseFloat(m[1], 64)
-		if err != nil {
-			return nil, parseerr
+	size, symbol, off, standard, qualified, err := parseQualifiedSizeSymbol(s)
+	if err != nil {
+		return nil, err
+	}
+	if !qualified {
+		var ok bool
+		if standard, ok = FindStandardBySymbol(symbol); !ok {
+			return nil, newSymbolParseError(s, off, symbol)
 		}
-		symbol := UnitSymbol(m[2])
-		standard, ok := FindStandardBySymbol(symbol)
-		if !ok {
-			return nil, stderr
+	}
+	return NewUnit(standard, size, symbol)
+}
+
+// ParseWithStandard parses a string representation of a unit size like Parse,
+// but measures symbols supported by several standards in the given standard,
+// i.e. "16 GB" is parsed as 16 GB of the JEDEC standard (16 * 1024^3 bytes)
+// when JEDEC is given. Symbols of other standards, and symbols qualified by a
+// standard, are parsed as by Parse
+func ParseWithStandard(s string, std UnitStandard) (Unit, error) {
+	size, symbol, off, standard, qualified, err := parseQualifiedSizeSymbol(s)
+	if err != nil {
+		return nil, err
+	}
+	if !qualified {
+		var ok bool
+		if standard, ok = findStandardForSymbol(std, symbol); !ok {
+			return nil, newSymbolParseError(s, off, symbol)
 		}
-		return NewUnit(standard, float64(size), symbol)
 	}
-	return nil, parseerr
+	return NewUnit(standard, size, symbol)
+}
+
+// ConvertTo takes a unit and converts it to an arbitrary UnitSymbol of any
+// standard, i.e. GiB to MB or Kib to kB. Symbols supported by several
+// standards (like Bit and Byte) keep the standard of the unit. A *BigUnit is
+// converted losslessly into another *BigUnit
+func ConvertTo(u Unit, sym UnitSymbol) (Unit, error) {
+	if !ValidateSymbol(u.Symbol()) {
+		return nil, NewErrUnitSymbolNotSupported(u.Symbol())
+	}
+	std, ok := findStandardForSymbol(u.Standard(), sym)
+	if !ok {
+		return nil, NewErrUnitSymbolNotSupported(sym)
+	}
+	bytes := byteRat(u)
+	if _, ok := u.(*BigUnit); ok {
+		return newBigUnitFromBytes(std, sym, bytes)
+	}
+	factor, ok := unitSymbolByteRat(std, sym)
+	if !ok {
+		return nil, NewErrUnitSymbolNotSupported(sym)
+	}
+	size, _ := bytes.Quo(bytes, factor).Float64()
+	return NewUnit(std, size, sym)
 }
 
-// ConvertUnitStd takes a unit from one standard and converts it to another
+// ConvertUnitStd takes a unit from one standard and converts it to another,
+// measured by the normalized symbol of the same kind (bit or byte) in the new
+// standard. A *BigUnit is converted losslessly into another *BigUnit
 func ConvertUnitStd(u Unit, std UnitStandard) (Unit, error) {
-	var (
-		sym       UnitSymbol
-		bytes     = u.ByteSize()
-		size, exp float64
-		ok        bool
-	)
-	switch std {
-	case IEC:
-		exp = math.Round(math.Log2(bytes) / 10)
-		size = bytes / math.Pow(2, exp)
-	case SI:
-		exp = math.Floor(math.Log10(bytes))
-		size = bytes / math.Pow10(int(exp))
-	default:
+	if b, ok := u.(*BigUnit); ok {
+		return b.ConvertStd(std)
+	}
+	if !ValidateSymbol(u.Symbol()) {
+		return nil, NewErrUnitSymbolNotSupported(u.Symbol())
+	}
+	bytes, _ := byteRat(u).Float64()
+	nu, err := NewUnit(std, bytes, Byte)
+	if err != nil {
 		return nil, NewErrUnitStandardNotSupported(std)
 	}
-	if size < 1 {
-		if sym, ok = FindLeastUnitSymbol(std, int(exp)); !ok {
-			return nil, fmt.Errorf(ErrUnitExponentNotSupportedf, exp)
-		}
-	} else {
-		if sym, ok = FindGreatestUnitSymbol(std, int(exp)); !ok {
-			return nil, fmt.Errorf(ErrUnitExponentNotSupportedf, exp)
-		}
+	if isBitUnitSymbol(u.Standard(), u.Symbol()) {
+		return Normalize(nu, PreferBits()), nil
 	}
-	return NewUnit(std, size, sym)
+	return Normalize(nu), nil
 }
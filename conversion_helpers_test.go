@@ -2,6 +2,8 @@ package bitty
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -54,12 +56,19 @@ func TestNewUnit(t *testing.T) {
 			&SIUnit{1, MB, 6},
 			nil,
 		},
+		{
+			JEDEC,
+			1,
+			MB,
+			&JEDECUnit{1, MB, 2},
+			nil,
+		},
 		{
 			UnitStandard(50),
 			1,
 			MB,
 			nil,
-			fmt.Errorf("%v is currently not a supported standard", 50),
+			&StandardError{UnitStandard(50), ErrUnitStandardNotSupported},
 		},
 	}
 	for _, u := range tt {
@@ -89,6 +98,11 @@ func TestParse(t *testing.T) {
 		{"1.64 Kib", c, nil},
 		{"-1 Mb", d, nil},
 		{"1MB", e, nil},
+		{"1 KB", &JEDECUnit{1, KB, 1}, nil},
+		{"16 GB (JEDEC)", &JEDECUnit{16, GB, 3}, nil},
+		{"512 Byte (IEC)", &IECUnit{512, Byte, 0}, nil},
+		{"16 GB (IEC)", nil, errb},
+		{"16 GB (FOO)", nil, errb},
 		{"one MiB", nil, erra},
 		{"1 Bab", nil, errb},
 	}
@@ -100,3 +114,202 @@ func TestParse(t *testing.T) {
 		assert.Equal(t, d.expected, u)
 	}
 }
+
+func ExampleConvertTo() {
+	a, _ := Parse("1 GiB")
+	b, _ := ConvertTo(a, MB)
+	c, _ := Parse("1 Kib")
+	d, _ := ConvertTo(c, kB)
+	fmt.Println(b)
+	fmt.Println(d)
+	// Output:
+	// 1073.741824 MB
+	// 0.128 kB
+}
+
+type testConvertTo struct {
+	in       string
+	to       UnitSymbol
+	expected float64
+	std      UnitStandard
+}
+
+// Values as defined by the SI brochure, 9th Edition, page 143 (145), and the
+// IEC binary prefixes
+func TestConvertTo(t *testing.T) {
+	tt := []testConvertTo{
+		// Within a standard, downwards and upwards
+		{"1 GiB", MiB, 1024, IEC},
+		{"1 GiB", KiB, 1048576, IEC},
+		{"1 GiB", Byte, 1073741824, IEC},
+		{"1 GiB", Bit, 8589934592, IEC},
+		{"1 GiB", Gib, 8, IEC},
+		{"1 GiB", Mib, 8192, IEC},
+		{"512 KiB", MiB, 0.5, IEC},
+		{"1 YiB", ZiB, 1024, IEC},
+		{"1 Kib", Byte, 128, IEC},
+		{"1 GB", MB, 1000, SI},
+		{"1 GB", kB, 1e6, SI},
+		{"1 GB", Byte, 1e9, SI},
+		{"1 GB", Gb, 8, SI},
+		{"1 MB", kb, 8000, SI},
+		{"1 YB", ZB, 1000, SI},
+		{"1 kB", Byte, 1000, SI},
+		{"250 MB", GB, 0.25, SI},
+		{"1 Bit", Byte, 0.125, SI},
+		{"1 Byte", Bit, 8, SI},
+		// Across standards
+		{"1 GiB", MB, 1073.741824, SI},
+		{"1 GiB", GB, 1.073741824, SI},
+		{"1 Kib", kB, 0.128, SI},
+		{"1 kB", Kib, 7.8125, IEC},
+		{"1 kB", KiB, 0.9765625, IEC},
+		{"1 TB", GiB, 931.3225746154785, IEC},
+		{"1 YiB", YB, 1.2089258196146292, SI},
+		{"1 MB", Mib, 7.62939453125, IEC},
+	}
+	for _, test := range tt {
+		u, err := Parse(test.in)
+		assert.NoError(t, err)
+		actual, err := ConvertTo(u, test.to)
+		assert.NoError(t, err)
+		assert.Equal(t, test.std, actual.Standard(), "%s to %s", test.in, test.to)
+		assert.Equal(t, test.to, actual.Symbol(), "%s to %s", test.in, test.to)
+		assert.InDelta(t, test.expected, actual.Size(), test.expected*1e-15, "%s to %s", test.in, test.to)
+		assert.InDelta(t, test.expected, u.SizeInUnit(test.to), test.expected*1e-15, "%s in %s", test.in, test.to)
+	}
+}
+
+func TestConvertTo_Errors(t *testing.T) {
+	u, _ := Parse("1 GiB")
+	_, err := ConvertTo(u, UnitSymbol("FooBar"))
+	assert.EqualError(t, err, "unit symbol not supported: FooBar")
+	_, err = ConvertTo(&SIUnit{1, UnitSymbol("FooBar"), 30}, MB)
+	assert.EqualError(t, err, "unit symbol not supported: FooBar")
+}
+
+func TestConvertTo_BigUnit(t *testing.T) {
+	a, _ := NewBigUnit(IEC, big.NewRat(1, 1), YiB)
+	b, err := ConvertTo(a, Bit)
+	assert.NoError(t, err)
+	assert.Equal(t, "9671406556917033397649408", b.(*BigUnit).Rat().RatString())
+}
+
+type testUnitSymbolToByteSize struct {
+	std      UnitStandard
+	sym      UnitSymbol
+	size     float64
+	expected float64
+}
+
+func TestUnitSymbolToByteSize(t *testing.T) {
+	tt := []testUnitSymbolToByteSize{
+		{SI, Bit, 8, 1},
+		{IEC, Bit, 1, 0.125},
+		{IEC, Byte, 3, 3},
+		{IEC, Kib, 1, 128},
+		{IEC, KiB, 1, 1024},
+		{IEC, Gib, 2, 268435456},
+		{SI, kb, 1, 125},
+		{SI, kB, 1, 1000},
+		{SI, GB, 1.5, 1.5e9},
+		{SI, dB, 1, 0.1},
+		{SI, db, 8, 0.1},
+		{SI, daB, 1, 10},
+		{SI, hB, 1, 100},
+		{SI, RB, 1, 1e27},
+		{SI, Qb, 8, 1e30},
+		{IEC, RiB, 1, math.Exp2(90)},
+		{IEC, Qib, 8, math.Exp2(100)},
+		{SI, GiB, 1, 0},
+		{IEC, UnitSymbol("FooBar"), 1, 0},
+	}
+	for _, test := range tt {
+		assert.Equal(t, test.expected, UnitSymbolToByteSize(test.std, test.sym, test.size), "%v %s", test.size, test.sym)
+		if test.expected != 0 {
+			assert.Equal(t, test.size, BytesToUnitSymbolSize(test.std, test.sym, test.expected), "%v bytes in %s", test.expected, test.sym)
+		}
+	}
+}
+
+func ExampleFindFloorUnitSymbolPair() {
+	_, ok := FindGreatestUnitSymbol(SI, 4)
+	pair, _ := FindFloorUnitSymbolPair(SI, 4)
+	fmt.Println(ok, pair.Greatest())
+	// Output:
+	// false kB
+}
+
+type testFindUnitSymbol struct {
+	std      UnitStandard
+	exp      int
+	expected UnitSymbol
+	ok       bool
+}
+
+func TestFindGreatestUnitSymbol(t *testing.T) {
+	tt := []testFindUnitSymbol{
+		{SI, -1, dB, true},
+		{SI, 0, Byte, true},
+		{SI, 1, daB, true},
+		{SI, 2, hB, true},
+		{SI, 3, kB, true},
+		{SI, 4, Byte, false},
+		{SI, 27, RB, true},
+		{SI, 30, QB, true},
+		{SI, 33, Byte, false},
+		{IEC, 9, RiB, true},
+		{IEC, 10, QiB, true},
+		{IEC, 11, Byte, false},
+		{IEC, -1, Byte, false},
+	}
+	for _, test := range tt {
+		sym, ok := FindGreatestUnitSymbol(test.std, test.exp)
+		assert.Equal(t, test.ok, ok, "%v %d", test.std, test.exp)
+		assert.Equal(t, test.expected, sym, "%v %d", test.std, test.exp)
+	}
+}
+
+func TestFindFloorUnitSymbolPair(t *testing.T) {
+	tt := []testFindUnitSymbol{
+		{SI, -2, "", false},
+		{SI, -1, dB, true},
+		{SI, 0, Byte, true},
+		{SI, 4, kB, true},
+		{SI, 8, MB, true},
+		{SI, 40, QB, true},
+		{IEC, -1, "", false},
+		{IEC, 12, QiB, true},
+	}
+	for _, test := range tt {
+		pair, ok := FindFloorUnitSymbolPair(test.std, test.exp)
+		assert.Equal(t, test.ok, ok, "%v %d", test.std, test.exp)
+		if ok {
+			assert.Equal(t, test.expected, pair.Greatest(), "%v %d", test.std, test.exp)
+		}
+	}
+}
+
+func TestParse_SIPrefixes(t *testing.T) {
+	tt := []parseExampleData{
+		{"1 dB", &SIUnit{1, dB, -1}, nil},
+		{"1 daB", &SIUnit{1, daB, 1}, nil},
+		{"2 hb", &SIUnit{2, hb, 2}, nil},
+		{"3 RB", &SIUnit{3, RB, 27}, nil},
+		{"4 Qb", &SIUnit{4, Qb, 30}, nil},
+		{"5 RiB", &IECUnit{5, RiB, 9}, nil},
+		{"6 Qib", &IECUnit{6, Qib, 10}, nil},
+	}
+	for _, d := range tt {
+		u, err := Parse(d.input)
+		assert.NoError(t, err, d.input)
+		assert.Equal(t, d.expected, u, d.input)
+	}
+	u, _ := Parse("25 dB")
+	b, err := UnitToBytes(u)
+	assert.NoError(t, err)
+	assert.Equal(t, Bytes(3), b)
+	assert.Equal(t, "1 quettabyte", fmt.Sprintf("%+v", &SIUnit{1, QB, 30}))
+	assert.Equal(t, "2 decibits", fmt.Sprintf("%+v", &SIUnit{2, db, -1}))
+	assert.Equal(t, "2 robibytes", fmt.Sprintf("%+v", &IECUnit{2, RiB, 9}))
+}
@@ -0,0 +1,235 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Clock provides the current time and sleeps to the types measuring or
+// limiting transfers over time, so that a fake clock can stand in for the
+// system clock in tests
+type Clock interface {
+	Now() time.Time
+	Sleep(time.Duration)
+}
+
+// systemClock is the Clock of the system, as given by time.Now and time.Sleep
+type systemClock struct{}
+
+// Now returns the current time of the system
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep pauses the current goroutine for a duration
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// DefaultRateWindow is the default time window of the moving average Rate of
+// a CountingReader or CountingWriter
+const DefaultRateWindow = 5 * time.Second
+
+// CountingOption configures a CountingReader or CountingWriter
+type CountingOption func(*counter)
+
+// WithClock sets the Clock measuring the Rate of a transfer, which is the
+// system clock by default
+func WithClock(c Clock) CountingOption {
+	return func(ct *counter) {
+		ct.clock = c
+	}
+}
+
+// WithRateWindow sets the time window of the moving average Rate of a
+// transfer, which is DefaultRateWindow by default
+func WithRateWindow(d time.Duration) CountingOption {
+	return func(ct *counter) {
+		if d > 0 {
+			ct.window = d
+		}
+	}
+}
+
+// WithProgress calls fn with the Unit transferred so far every time a
+// multiple of every is transferred, i.e. every 10 MiB. The callback is called
+// by the goroutine reading or writing, so it should return quickly
+func WithProgress(every Unit, fn func(transferred Unit)) CountingOption {
+	return func(ct *counter) {
+		if b, err := UnitToBytes(every); err == nil && b > 0 {
+			ct.every = int64(b)
+			ct.progress = fn
+		}
+	}
+}
+
+// rateSamples is the number of samples kept per rate window
+const rateSamples = 16
+
+// rateSample is the number of bytes transferred at a point in time
+type rateSample struct {
+	at time.Time
+	n  int64
+}
+
+// counter counts the bytes of a transfer, measuring its moving average rate
+type counter struct {
+	// n is accessed atomically, and is kept first for 64-bit alignment
+	n        int64
+	clock    Clock
+	window   time.Duration
+	every    int64
+	progress func(Unit)
+
+	mu      sync.Mutex
+	samples []rateSample
+}
+
+// newCounter returns a counter configured by opts, started at the current time
+func newCounter(opts []CountingOption) *counter {
+	ct := &counter{clock: systemClock{}, window: DefaultRateWindow}
+	for _, opt := range opts {
+		opt(ct)
+	}
+	ct.samples = []rateSample{{ct.clock.Now(), 0}}
+	return ct
+}
+
+// add counts n more bytes, sampling the rate and reporting progress
+func (ct *counter) add(n int) {
+	if n <= 0 {
+		return
+	}
+	total := atomic.AddInt64(&ct.n, int64(n))
+	now := ct.clock.Now()
+	ct.mu.Lock()
+	// Samples closer than a fraction of the window are merged, which bounds
+	// the number of samples kept for transfers of many small reads or writes
+	if i := len(ct.samples) - 1; i > 0 && now.Sub(ct.samples[i-1].at) < ct.window/rateSamples {
+		ct.samples[i] = rateSample{now, total}
+	} else {
+		ct.samples = append(ct.samples, rateSample{now, total})
+	}
+	ct.trim(now)
+	ct.mu.Unlock()
+	if ct.progress != nil && (total-int64(n))/ct.every != total/ct.every {
+		ct.progress(bytesUnit(total))
+	}
+}
+
+// trim drops the samples older than the window, keeping the latest of them as
+// the start of the window. It must be called with mu held
+func (ct *counter) trim(now time.Time) {
+	start := now.Add(-ct.window)
+	i := 0
+	for i+1 < len(ct.samples) && !ct.samples[i+1].at.After(start) {
+		i++
+	}
+	ct.samples = append(ct.samples[:0], ct.samples[i:]...)
+}
+
+// transferred returns the Unit transferred so far
+func (ct *counter) transferred() Unit {
+	return bytesUnit(atomic.LoadInt64(&ct.n))
+}
+
+// rate returns the moving average Rate over the window
+func (ct *counter) rate() Rate {
+	now := ct.clock.Now()
+	ct.mu.Lock()
+	ct.trim(now)
+	first := ct.samples[0]
+	ct.mu.Unlock()
+	elapsed := now.Sub(first.at)
+	if elapsed <= 0 {
+		return Rate{&IECUnit{0, Byte, 0}, time.Second}
+	}
+	bps := float64(atomic.LoadInt64(&ct.n)-first.n) / elapsed.Seconds()
+	return Rate{Normalize(&IECUnit{bps, Byte, 0}), time.Second}
+}
+
+// bytesUnit returns n bytes as an IECUnit of the best fitting byte symbol
+func bytesUnit(n int64) Unit {
+	u, _ := Bytes(n).ToUnit(IEC)
+	return u
+}
+
+// CountingReader is an io.Reader counting the bytes read from an underlying
+// io.Reader. Its methods are safe to call concurrently with Read, i.e. from a
+// goroutine showing the progress of a download
+type CountingReader struct {
+	r  io.Reader
+	ct *counter
+}
+
+// NewCountingReader returns a CountingReader reading from r
+func NewCountingReader(r io.Reader, opts ...CountingOption) *CountingReader {
+	return &CountingReader{r, newCounter(opts)}
+}
+
+// Read implements io.Reader, counting the bytes read
+func (c *CountingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.ct.add(n)
+	return n, err
+}
+
+// Transferred returns the Unit read so far, i.e. 10 MiB
+func (c *CountingReader) Transferred() Unit {
+	return c.ct.transferred()
+}
+
+// Rate returns the moving average Rate of reading over the rate window, per
+// second
+func (c *CountingReader) Rate() Rate {
+	return c.ct.rate()
+}
+
+// CountingWriter is an io.Writer counting the bytes written to an underlying
+// io.Writer. Its methods are safe to call concurrently with Write, i.e. from a
+// goroutine showing the progress of an upload
+type CountingWriter struct {
+	w  io.Writer
+	ct *counter
+}
+
+// NewCountingWriter returns a CountingWriter writing to w
+func NewCountingWriter(w io.Writer, opts ...CountingOption) *CountingWriter {
+	return &CountingWriter{w, newCounter(opts)}
+}
+
+// Write implements io.Writer, counting the bytes written
+func (c *CountingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.ct.add(n)
+	return n, err
+}
+
+// Transferred returns the Unit written so far, i.e. 10 MiB
+func (c *CountingWriter) Transferred() Unit {
+	return c.ct.transferred()
+}
+
+// Rate returns the moving average Rate of writing over the rate window, per
+// second
+func (c *CountingWriter) Rate() Rate {
+	return c.ct.rate()
+}
@@ -0,0 +1,169 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a Clock which only advances when told to
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Sleep advances the clock rather than sleeping
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.Advance(d)
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func ExampleNewCountingReader() {
+	every, _ := Parse("1 MiB")
+	r := NewCountingReader(strings.NewReader(strings.Repeat("x", 3<<20)), WithProgress(every, func(u Unit) {
+		fmt.Println("read", u)
+	}))
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(r, 2<<20+512<<10))
+	fmt.Println(r.Transferred())
+	// Output:
+	// read 1 MiB
+	// read 2 MiB
+	// 2.5 MiB
+}
+
+func TestCountingReader(t *testing.T) {
+	clock := newFakeClock()
+	r := NewCountingReader(bytes.NewReader(make([]byte, 8<<20)), WithClock(clock))
+	assert.Equal(t, &IECUnit{0, Byte, 0}, r.Transferred())
+	assert.Equal(t, float64(0), r.Rate().BytesPerSecond())
+
+	buf := make([]byte, 1<<20)
+	for i := 0; i < 4; i++ {
+		clock.Advance(time.Second)
+		n, err := r.Read(buf)
+		assert.NoError(t, err)
+		assert.Equal(t, 1<<20, n)
+	}
+	assert.Equal(t, &IECUnit{4, MiB, 2}, r.Transferred())
+	assert.Equal(t, "1 MiB/s", r.Rate().String())
+
+	// Only the last 5 seconds count towards the rate
+	clock.Advance(4 * time.Second)
+	assert.Equal(t, float64(1<<20)/5, r.Rate().BytesPerSecond())
+	clock.Advance(time.Minute)
+	assert.Equal(t, float64(0), r.Rate().BytesPerSecond())
+
+	n, err := io.Copy(ioutil.Discard, r)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4<<20), n)
+	assert.Equal(t, &IECUnit{8, MiB, 2}, r.Transferred())
+}
+
+func TestCountingWriter(t *testing.T) {
+	clock := newFakeClock()
+	var got []string
+	every, _ := NewSIUnit(1, MB)
+	var buf bytes.Buffer
+	w := NewCountingWriter(&buf, WithClock(clock), WithRateWindow(10*time.Second), WithProgress(every, func(u Unit) {
+		got = append(got, fmt.Sprintf("%d", u))
+	}))
+	chunk := make([]byte, 400000)
+	for i := 0; i < 10; i++ {
+		clock.Advance(time.Second)
+		n, err := w.Write(chunk)
+		assert.NoError(t, err)
+		assert.Equal(t, len(chunk), n)
+	}
+	assert.Equal(t, 4000000, buf.Len())
+	assert.Equal(t, float64(4000000), w.Transferred().ByteSize())
+	assert.Equal(t, []string{"1200000", "2000000", "3200000", "4000000"}, got)
+	assert.Equal(t, float64(400000), w.Rate().BytesPerSecond())
+}
+
+type shortWriter struct{}
+
+func (shortWriter) Write(p []byte) (int, error) {
+	return len(p) / 2, io.ErrShortWrite
+}
+
+func TestCountingWriter_ShortWrite(t *testing.T) {
+	w := NewCountingWriter(shortWriter{})
+	n, err := w.Write(make([]byte, 10))
+	assert.Equal(t, 5, n)
+	assert.Equal(t, io.ErrShortWrite, err)
+	assert.Equal(t, float64(5), w.Transferred().ByteSize())
+}
+
+func TestCountingReader_Concurrent(t *testing.T) {
+	r := NewCountingReader(bytes.NewReader(make([]byte, 1<<20)))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, 100)
+		for {
+			if _, err := r.Read(buf); err != nil {
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			assert.Equal(t, &IECUnit{1, MiB, 2}, r.Transferred())
+			return
+		default:
+			_ = r.Transferred()
+			_ = r.Rate()
+		}
+	}
+}
+
+func TestCounter_SamplesAreBounded(t *testing.T) {
+	clock := newFakeClock()
+	r := NewCountingReader(bytes.NewReader(make([]byte, 1<<20)), WithClock(clock))
+	buf := make([]byte, 1)
+	for i := 0; i < 10000; i++ {
+		clock.Advance(time.Millisecond)
+		_, _ = r.Read(buf)
+	}
+	assert.True(t, len(r.ct.samples) <= rateSamples+2, "%d samples", len(r.ct.samples))
+	assert.InDelta(t, 1000, r.Rate().BytesPerSecond(), 1)
+}
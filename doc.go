@@ -1,6 +1,23 @@
 // Package bitty conforms to IEC and SI Standards
 // Conversions taken from SI Brochure 9, EN, Chapter 3, page 143 (145)
 // https://www.bipm.org/utils/common/pdf/si-brochure/SI-Brochure-9.pdf
+//
+// The JEDEC 100B.01 memory standard is supported as well, in which KB, MB, GB
+// and TB denote powers of 1024. As MB, GB and TB are shared with SI, Parse
+// reads them as SI symbols, while KB is only a JEDEC symbol; use
+// ParseWithStandard in order to read them as JEDEC symbols.
+//
+// Units implement fmt.Formatter with the following verbs:
+//
+//	%v, %s  the size and symbol, i.e. "1.5 GiB"
+//	%h      the size and symbol of the best fitting symbol, i.e. "1.5 GiB"
+//	        for 1536 MiB
+//	%d      the size measured in bytes, rounded to the nearest byte
+//
+// A precision sets the number of decimals of the size, i.e. %.2v prints
+// "1.50 GiB", while the plus flag prints the long name of the symbol, i.e.
+// %+v prints "1.5 gibibytes". A width pads the whole Unit, which is left
+// justified by the minus flag.
 package bitty
 
 /*
@@ -16,35 +16,246 @@ package bitty
 	limitations under the License.
 */
 
-import "github.com/pkg/errors"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
 
 // Error messages for Units
 var (
 	ErrUnitSymbolNotSupported       = errors.New("unit symbol not supported")
 	ErrUnitSymbolNotSupportedf      = string(ErrUnitSymbolNotSupported.Error() + ": %s")
-	ErrUnitSymbolEmptyNotSupportedf = errors.Errorf(ErrUnitSymbolNotSupported.Error() + ": empty symbol")
+	ErrUnitSymbolEmptyNotSupportedf = error(&SymbolError{"", ErrUnitSymbolNotSupported})
+	ErrUnitSymbolAmbiguous          = errors.New("unit symbol ambiguous")
 	ErrUnitExponentNotSupported     = errors.New("unit exponent not supported")
 	ErrUnitExponentNotSupportedf    = string(ErrUnitExponentNotSupported.Error() + ": %s")
 	ErrUnitStandardNotSupported     = errors.New("unit standard not supported")
 	ErrUnitStandardNotSupportedf    = string(ErrUnitStandardNotSupported.Error() + ": %s")
 	ErrUnitCouldNotBeParsed         = errors.New("unit could not be parsed")
 	ErrUnitCouldNotBeParsedf        = string(ErrUnitCouldNotBeParsed.Error() + ": %s")
+	ErrUnitMultiplyNotSupported     = errors.New("unit multiplication by unit not supported")
+	ErrUnitMultiplyNotSupportedf    = string(ErrUnitMultiplyNotSupported.Error() + ": %s * %s")
+	ErrUnitDivideNotSupported       = errors.New("unit division by unit not supported")
+	ErrUnitDivideNotSupportedf      = string(ErrUnitDivideNotSupported.Error() + ": %s / %s")
+	ErrUnitDivideByZero             = errors.New("unit division by zero")
+	ErrUnitOverflow                 = errors.New("unit size overflows int64 bytes")
+	ErrUnitBelowMinimum             = errors.New("unit size below minimum")
+	ErrUnitAboveMaximum             = errors.New("unit size above maximum")
+	ErrUnitStandardInvalid          = errors.New("unit standard invalid")
+	ErrUnitAlreadyRegistered        = errors.New("unit already registered")
+	ErrUnitRateNotPositive          = errors.New("unit rate not positive")
 )
 
-// NewErrUnitSymbolNotSupported returns an error formatted for a given UnitSymbol
+// SymbolError records an error with a UnitSymbol, i.e. an unsupported symbol
+type SymbolError struct {
+	Symbol UnitSymbol
+	Err    error
+}
+
+// Error returns the message of a SymbolError, i.e.
+// unit symbol not supported: FooBar
+func (e *SymbolError) Error() string {
+	if e.Symbol == "" {
+		return e.Err.Error() + ": empty symbol"
+	}
+	return e.Err.Error() + ": " + string(e.Symbol)
+}
+
+// Unwrap returns the cause of a SymbolError, i.e. ErrUnitSymbolNotSupported
+func (e *SymbolError) Unwrap() error {
+	return e.Err
+}
+
+// StandardError records an error with a UnitStandard, i.e. an unsupported
+// standard
+type StandardError struct {
+	Standard UnitStandard
+	Err      error
+}
+
+// Error returns the message of a StandardError, i.e.
+// unit standard not supported: 50
+func (e *StandardError) Error() string {
+	return e.Err.Error() + ": " + e.Standard.String()
+}
+
+// Unwrap returns the cause of a StandardError, i.e.
+// ErrUnitStandardNotSupported
+func (e *StandardError) Unwrap() error {
+	return e.Err
+}
+
+// RegistrationError records an error registering a UnitStandard or a
+// UnitSymbolPair in a Registry, i.e. an invalid or already registered
+// standard name, or an already registered exponent
+type RegistrationError struct {
+	// Name is the name of the standard, or the exponent of the pair, i.e.
+	// "storage" or "exponent 12"
+	Name string
+	Err  error
+}
+
+// Error returns the message of a RegistrationError, i.e.
+// unit already registered: storage
+func (e *RegistrationError) Error() string {
+	return e.Err.Error() + ": " + e.Name
+}
+
+// Unwrap returns the cause of a RegistrationError, i.e.
+// ErrUnitAlreadyRegistered
+func (e *RegistrationError) Unwrap() error {
+	return e.Err
+}
+
+// ArithmeticError records an error applying an arithmetic operator ("+", "-",
+// "*" or "/") to two operands, which are the symbols of units or formatted
+// scalars
+type ArithmeticError struct {
+	Op    string
+	Left  string
+	Right string
+	Err   error
+}
+
+// Error returns the message of an ArithmeticError, i.e.
+// unit multiplication by unit not supported: GiB * MiB
+func (e *ArithmeticError) Error() string {
+	return fmt.Sprintf("%v: %s %s %s", e.Err, e.Left, e.Op, e.Right)
+}
+
+// Unwrap returns the cause of an ArithmeticError, i.e. ErrUnitDivideByZero
+func (e *ArithmeticError) Unwrap() error {
+	return e.Err
+}
+
+// newArithmeticError returns an *ArithmeticError for two units or scalars
+func newArithmeticError(op string, l, r interface{}, err error) error {
+	return &ArithmeticError{op, arithmeticOperand(l), arithmeticOperand(r), err}
+}
+
+// arithmeticOperand formats a Unit by its symbol and a scalar as a number
+func arithmeticOperand(v interface{}) string {
+	switch o := v.(type) {
+	case Unit:
+		return string(o.Symbol())
+	case float64:
+		return strconv.FormatFloat(o, 'g', -1, 64)
+	default:
+		return fmt.Sprint(o)
+	}
+}
+
+// NewErrUnitSymbolNotSupported returns a *SymbolError for a given UnitSymbol
 func NewErrUnitSymbolNotSupported(s UnitSymbol) error {
 	if s == "" {
 		return ErrUnitSymbolEmptyNotSupportedf
 	}
-	return errors.Errorf(ErrUnitSymbolNotSupportedf, s)
+	return &SymbolError{s, ErrUnitSymbolNotSupported}
 }
 
-// NewErrUnitStandardNotSupported returns an error formatted for a given UnitStandard
+// NewErrUnitExponentNotSupported returns an error wrapping
+// ErrUnitExponentNotSupported for a given exponent
+func NewErrUnitExponentNotSupported(e int) error {
+	return fmt.Errorf("%w: %d", ErrUnitExponentNotSupported, e)
+}
+
+// NewErrUnitStandardNotSupported returns a *StandardError for a given
+// UnitStandard
 func NewErrUnitStandardNotSupported(s UnitStandard) error {
-	return errors.Errorf(ErrUnitSymbolNotSupportedf, s)
+	return &StandardError{s, ErrUnitStandardNotSupported}
 }
 
-// NewErrUnitCouldNotBeParsed returns an error formatted for a given Unit
+// NewErrUnitCouldNotBeParsed returns an error wrapping ErrUnitCouldNotBeParsed
+// for a given input
 func NewErrUnitCouldNotBeParsed(s string) error {
-	return errors.Errorf(ErrUnitCouldNotBeParsedf, s)
+	return fmt.Errorf("%w: %s", ErrUnitCouldNotBeParsed, s)
+}
+
+// NewErrUnitMultiplyNotSupported returns an *ArithmeticError for two given
+// UnitSymbols
+func NewErrUnitMultiplyNotSupported(l, r UnitSymbol) error {
+	return &ArithmeticError{"*", string(l), string(r), ErrUnitMultiplyNotSupported}
+}
+
+// NewErrUnitDivideNotSupported returns an *ArithmeticError for two given
+// UnitSymbols
+func NewErrUnitDivideNotSupported(l, r UnitSymbol) error {
+	return &ArithmeticError{"/", string(l), string(r), ErrUnitDivideNotSupported}
+}
+
+// NewErrUnitBelowMinimum returns an error wrapping ErrUnitBelowMinimum for a
+// given Unit and the minimum it fell below
+func NewErrUnitBelowMinimum(u, min Unit) error {
+	return fmt.Errorf("%w: %s < %s", ErrUnitBelowMinimum, u, min)
+}
+
+// NewErrUnitAboveMaximum returns an error wrapping ErrUnitAboveMaximum for a
+// given Unit and the maximum it exceeded
+func NewErrUnitAboveMaximum(u, max Unit) error {
+	return fmt.Errorf("%w: %s > %s", ErrUnitAboveMaximum, u, max)
+}
+
+// ParseComponent names the component of an input which could not be parsed
+type ParseComponent int
+
+// Parse component enums
+const (
+	// ComponentNumber is the size of a unit, i.e. "1.5" in "1.5 GiB"
+	ComponentNumber ParseComponent = iota
+	// ComponentSymbol is the symbol of a unit, i.e. "GiB" in "1.5 GiB"
+	ComponentSymbol
+	// ComponentStandard is the standard of a unit, or the name of a standard
+	ComponentStandard
+	// ComponentExpression is the syntax or evaluation of an expression
+	ComponentExpression
+	// ComponentDuration is the time denominator of a rate, i.e. "s" in
+	// "1.5 GiB/s"
+	ComponentDuration
+)
+
+// String returns the name of a ParseComponent, i.e. "number"
+func (c ParseComponent) String() string {
+	switch c {
+	case ComponentNumber:
+		return "number"
+	case ComponentSymbol:
+		return "symbol"
+	case ComponentStandard:
+		return "standard"
+	case ComponentExpression:
+		return "expression"
+	case ComponentDuration:
+		return "duration"
+	default:
+		return strconv.Itoa(int(c))
+	}
+}
+
+// ParseError is returned by the parsing functions when an input cannot be
+// parsed, giving the byte offset and component of the input at fault. It
+// unwraps to its cause (like ErrUnitSymbolNotSupported), and always matches
+// ErrUnitCouldNotBeParsed with errors.Is
+type ParseError struct {
+	Input     string
+	Offset    int
+	Component ParseComponent
+	Err       error
+}
+
+// Error returns the message of a ParseError, i.e.
+// parsing "1 FooBar": invalid symbol at offset 2: unit symbol not supported
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parsing %q: invalid %s at offset %d: %v", e.Input, e.Component, e.Offset, e.Err)
+}
+
+// Unwrap returns the cause of a ParseError
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrUnitCouldNotBeParsed, which every
+// ParseError matches
+func (e *ParseError) Is(target error) bool {
+	return target == ErrUnitCouldNotBeParsed
 }
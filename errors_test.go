@@ -0,0 +1,238 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleParseError() {
+	_, err := Parse("1 FooBar")
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		fmt.Println(pe.Component, pe.Offset)
+	}
+	fmt.Println(errors.Is(err, ErrUnitSymbolNotSupported))
+	fmt.Println(err)
+	// Output:
+	// symbol 2
+	// true
+	// parsing "1 FooBar": invalid symbol at offset 2: unit symbol not supported
+}
+
+type testParseError struct {
+	input     string
+	parse     func(string) error
+	offset    int
+	component ParseComponent
+	err       error
+}
+
+func TestParseError(t *testing.T) {
+	parse := func(s string) error {
+		_, err := Parse(s)
+		return err
+	}
+	parseIEC := func(s string) error {
+		var u IECUnit
+		return u.UnmarshalText([]byte(s))
+	}
+	parseJEDEC := func(s string) error {
+		_, err := ParseWithStandard(s, JEDEC)
+		return err
+	}
+	parseStd := func(s string) error {
+		_, err := ParseUnitStandard(s)
+		return err
+	}
+	parseQuantity := func(s string) error {
+		_, err := ParseQuantity(s)
+		return err
+	}
+	parseLenient := func(s string) error {
+		_, err := ParseLenient(s)
+		return err
+	}
+	tt := []testParseError{
+		{"one MiB", parse, 0, ComponentNumber, strconv.ErrSyntax},
+		{"1.2.3 MiB", parse, 0, ComponentNumber, strconv.ErrSyntax},
+		{strings.Repeat("9", 400) + " MiB", parse, 0, ComponentNumber, strconv.ErrRange},
+		{"1 FooBar", parse, 2, ComponentSymbol, ErrUnitSymbolNotSupported},
+		{"1FooBar", parse, 1, ComponentSymbol, ErrUnitSymbolNotSupported},
+		{"1 ", parse, 2, ComponentSymbol, ErrUnitSymbolNotSupported},
+		{"1 M-B", parse, 2, ComponentSymbol, ErrUnitSymbolNotSupported},
+		{"1 XB", parseJEDEC, 2, ComponentSymbol, ErrUnitSymbolNotSupported},
+		{"1 GB", parseIEC, 2, ComponentStandard, ErrUnitStandardNotSupported},
+		{"1 FooBar", parseIEC, 2, ComponentSymbol, ErrUnitSymbolNotSupported},
+		{"ISO", parseStd, 0, ComponentStandard, ErrUnitStandardNotSupported},
+		{"1m", parseQuantity, 1, ComponentSymbol, ErrUnitSymbolNotSupported},
+		{"Gi", parseQuantity, 0, ComponentNumber, strconv.ErrSyntax},
+		{"  10 gigs", parseLenient, 5, ComponentSymbol, ErrUnitSymbolNotSupported},
+		{" ten GB", parseLenient, 1, ComponentNumber, strconv.ErrSyntax},
+	}
+	for _, test := range tt {
+		err := test.parse(test.input)
+		var pe *ParseError
+		if !assert.True(t, errors.As(err, &pe), "%q: %v", test.input, err) {
+			continue
+		}
+		assert.Equal(t, test.input, pe.Input)
+		assert.Equal(t, test.offset, pe.Offset, test.input)
+		assert.Equal(t, test.component, pe.Component, test.input)
+		assert.True(t, errors.Is(err, test.err), "%q: %v", test.input, err)
+		assert.True(t, errors.Is(err, ErrUnitCouldNotBeParsed), test.input)
+	}
+}
+
+func TestNewErrUnitStandardNotSupported(t *testing.T) {
+	assert.EqualError(t, NewErrUnitStandardNotSupported(JEDEC), "unit standard not supported: JEDEC")
+}
+
+func ExampleArithmeticError() {
+	u, _ := Parse("1 GiB")
+	_, err := DivideUnit(u, 0)
+	var ae *ArithmeticError
+	if errors.As(err, &ae) {
+		fmt.Println(ae.Left, ae.Op, ae.Right)
+	}
+	fmt.Println(errors.Is(err, ErrUnitDivideByZero))
+	fmt.Println(err)
+	// Output:
+	// GiB / 0
+	// true
+	// unit division by zero: GiB / 0
+}
+
+func TestSymbolError(t *testing.T) {
+	err := NewErrUnitSymbolNotSupported("FooBar")
+	var se *SymbolError
+	assert.True(t, errors.As(err, &se))
+	assert.Equal(t, UnitSymbol("FooBar"), se.Symbol)
+	assert.True(t, errors.Is(err, ErrUnitSymbolNotSupported))
+	assert.EqualError(t, err, "unit symbol not supported: FooBar")
+	assert.EqualError(t, NewErrUnitSymbolNotSupported(""), "unit symbol not supported: empty symbol")
+	assert.True(t, errors.Is(ErrUnitSymbolEmptyNotSupportedf, ErrUnitSymbolNotSupported))
+
+	_, err = ConvertTo(&SIUnit{1, MB, 6}, "FooBar")
+	assert.True(t, errors.As(err, &se))
+	_, err = NewIECUnit(1, MB)
+	assert.True(t, errors.Is(err, ErrUnitSymbolNotSupported))
+}
+
+func TestStandardError(t *testing.T) {
+	_, err := NewUnit(UnitStandard(50), 1, MB)
+	var se *StandardError
+	assert.True(t, errors.As(err, &se))
+	assert.Equal(t, UnitStandard(50), se.Standard)
+	assert.True(t, errors.Is(err, ErrUnitStandardNotSupported))
+	assert.EqualError(t, err, "unit standard not supported: 50")
+}
+
+func TestRegistrationError(t *testing.T) {
+	r := NewRegistry()
+	std, _ := r.RegisterStandard("storage", 2, 1)
+	_, err := r.RegisterStandard("storage", 2, 1)
+	var re *RegistrationError
+	assert.True(t, errors.As(err, &re))
+	assert.Equal(t, "storage", re.Name)
+	assert.True(t, errors.Is(err, ErrUnitAlreadyRegistered))
+	assert.EqualError(t, err, "unit already registered: storage")
+	_, err = r.RegisterStandard("", 2, 1)
+	assert.True(t, errors.As(err, &re))
+	assert.True(t, errors.Is(err, ErrUnitStandardInvalid))
+
+	assert.NoError(t, r.Register(NewCustomUnitSymbolPair(std, "pagebit", "page", 12)))
+	err = r.Register(NewCustomUnitSymbolPair(std, "pgb", "pg", 12))
+	assert.True(t, errors.As(err, &re))
+	assert.EqualError(t, err, "unit already registered: exponent 12")
+	err = r.Register(NewCustomUnitSymbolPair(std, "pb", "page", 13))
+	var se *SymbolError
+	assert.True(t, errors.As(err, &se))
+	assert.Equal(t, UnitSymbol("page"), se.Symbol)
+	assert.True(t, errors.Is(err, ErrUnitAlreadyRegistered))
+	err = r.Register(NewCustomUnitSymbolPair(std, "x", "x", 14))
+	assert.True(t, errors.As(err, &se))
+	assert.True(t, errors.Is(err, ErrUnitAlreadyRegistered))
+}
+
+type testArithmeticError struct {
+	name     string
+	fn       func() error
+	expected ArithmeticError
+}
+
+func TestArithmeticError(t *testing.T) {
+	gib := &IECUnit{1, GiB, 3}
+	mb := &SIUnit{1, MB, 6}
+	foo := &SIUnit{1, UnitSymbol("FooBar"), 30}
+	tt := []testArithmeticError{
+		{"AddUnits", func() error {
+			_, err := AddUnits(gib, foo)
+			return err
+		}, ArithmeticError{"+", "GiB", "FooBar", ErrUnitSymbolNotSupported}},
+		{"SubtractUnits", func() error {
+			_, err := SubtractUnits(foo, foo)
+			return err
+		}, ArithmeticError{"-", "FooBar", "FooBar", ErrUnitSymbolNotSupported}},
+		{"MultiplyUnits", func() error {
+			_, err := MultiplyUnits(gib, mb)
+			return err
+		}, ArithmeticError{"*", "GiB", "MB", ErrUnitMultiplyNotSupported}},
+		{"MultiplyUnit", func() error {
+			_, err := MultiplyUnit(foo, 2.5)
+			return err
+		}, ArithmeticError{"*", "FooBar", "2.5", ErrUnitSymbolNotSupported}},
+		{"DivideUnits", func() error {
+			_, err := DivideUnits(gib, &SIUnit{0, MB, 6})
+			return err
+		}, ArithmeticError{"/", "GiB", "MB", ErrUnitDivideByZero}},
+		{"DivideE", func() error {
+			_, err := gib.DivideE(mb)
+			return err
+		}, ArithmeticError{"/", "GiB", "MB", ErrUnitDivideNotSupported}},
+	}
+	for _, test := range tt {
+		err := test.fn()
+		var ae *ArithmeticError
+		if assert.True(t, errors.As(err, &ae), "%s: %v", test.name, err) {
+			assert.Equal(t, test.expected, *ae, test.name)
+			assert.True(t, errors.Is(err, test.expected.Err), test.name)
+		}
+	}
+}
+
+func TestErrorWrapping(t *testing.T) {
+	tt := []struct {
+		err      error
+		sentinel error
+	}{
+		{NewErrUnitExponentNotSupported(11), ErrUnitExponentNotSupported},
+		{NewErrUnitCouldNotBeParsed("one MiB"), ErrUnitCouldNotBeParsed},
+		{NewErrUnitBelowMinimum(&SIUnit{1, MB, 6}, &IECUnit{1, MiB, 2}), ErrUnitBelowMinimum},
+		{NewErrUnitAboveMaximum(&IECUnit{1, TiB, 4}, &SIUnit{1, TB, 12}), ErrUnitAboveMaximum},
+	}
+	for _, test := range tt {
+		assert.True(t, errors.Is(test.err, test.sentinel), test.err.Error())
+	}
+	assert.EqualError(t, NewErrUnitBelowMinimum(&SIUnit{1, MB, 6}, &IECUnit{1, MiB, 2}), "unit size below minimum: 1 MB < 1 MiB")
+}
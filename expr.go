@@ -0,0 +1,310 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// exprTokenKind is the kind of a token of a size expression
+type exprTokenKind int
+
+const (
+	exprEOF exprTokenKind = iota
+	exprNumber
+	exprWord
+	exprOperator
+	exprLeftParen
+	exprRightParen
+)
+
+// exprToken is a token of a size expression, with its byte offset in the input
+type exprToken struct {
+	kind   exprTokenKind
+	text   string
+	offset int
+}
+
+// exprValue is the value of a (sub) expression: either a Unit, or a
+// dimensionless scalar
+type exprValue struct {
+	unit   Unit
+	scalar float64
+}
+
+// isUnit reports whether the value is a Unit rather than a scalar
+func (v exprValue) isUnit() bool {
+	return v.unit != nil
+}
+
+// exprParser is a recursive descent parser evaluating a size expression
+type exprParser struct {
+	input  string
+	tokens []exprToken
+	pos    int
+}
+
+// ParseExpr parses and evaluates a size expression, i.e. "1 GiB 512 MiB",
+// "3x 4TB" or "2 * (4 GiB + 100 MB) / 3". Sizes are parsed as by Parse, while
+// juxtaposed sizes are summed. Sizes may be added to and subtracted from each
+// other, multiplied and divided by scalars (with "*", "x" or "/"), and divided
+// by each other into scalars. Sums and differences are measured by the symbol
+// of their left operand, as by AddUnits and SubtractUnits. Errors are
+// returned as *ParseError, giving the offset of the offending token
+func ParseExpr(s string) (Unit, error) {
+	tokens, err := tokenizeExpr(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{input: s, tokens: tokens}
+	v, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != exprEOF {
+		return nil, p.errorf(t, "unexpected %q", t.text)
+	}
+	if !v.isUnit() {
+		return nil, p.errorf(exprToken{offset: 0}, "expression has no unit")
+	}
+	return v.unit, nil
+}
+
+// errorf returns a *ParseError of the expression at the offset of a token
+func (p *exprParser) errorf(t exprToken, format string, args ...interface{}) error {
+	return &ParseError{p.input, t.offset, ComponentExpression, fmt.Errorf(format, args...)}
+}
+
+// tokenizeExpr splits a size expression into tokens
+func tokenizeExpr(s string) ([]exprToken, error) {
+	var tokens []exprToken
+	for i := 0; i < len(s); {
+		c := s[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case isExprDigit(c) || c == '.':
+			for i < len(s) && (isExprDigit(s[i]) || s[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, exprToken{exprNumber, s[start:i], start})
+			continue
+		case isExprLetter(c):
+			for i < len(s) && isExprLetter(s[i]) {
+				i++
+			}
+			tokens = append(tokens, exprToken{exprWord, s[start:i], start})
+			continue
+		case c == '+' || c == '-' || c == '*' || c == '/':
+			tokens = append(tokens, exprToken{exprOperator, s[i : i+1], start})
+		case c == '(':
+			tokens = append(tokens, exprToken{exprLeftParen, "(", start})
+		case c == ')':
+			tokens = append(tokens, exprToken{exprRightParen, ")", start})
+		default:
+			return nil, &ParseError{s, start, ComponentExpression, fmt.Errorf("unexpected %q", s[i:i+1])}
+		}
+		i++
+	}
+	return append(tokens, exprToken{exprEOF, "end of expression", len(s)}), nil
+}
+
+// isExprDigit reports whether c is an ASCII digit
+func isExprDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isExprLetter reports whether c is an ASCII letter or an underscore
+func isExprLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+}
+
+// peek returns the current token
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+// next returns the current token and advances to the next one
+func (p *exprParser) next() exprToken {
+	t := p.tokens[p.pos]
+	if t.kind != exprEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isMultiplication reports whether a token multiplies, i.e. "*" or "x"
+func isMultiplication(t exprToken) bool {
+	return (t.kind == exprOperator && t.text == "*") || (t.kind == exprWord && t.text == "x")
+}
+
+// parseSum parses terms separated by "+", "-" or nothing (juxtaposed sizes)
+func (p *exprParser) parseSum() (exprValue, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return exprValue{}, err
+	}
+	for {
+		t := p.peek()
+		op := t.text
+		switch {
+		case t.kind == exprOperator && (op == "+" || op == "-"):
+			p.next()
+		case t.kind == exprNumber:
+			// Juxtaposed sizes, i.e. "1 GiB 512 MiB", are summed
+			op = "+"
+		default:
+			return left, nil
+		}
+		right, err := p.parseProduct()
+		if err != nil {
+			return exprValue{}, err
+		}
+		if t.kind == exprNumber && (!left.isUnit() || !right.isUnit()) {
+			return exprValue{}, p.errorf(t, "missing operator before %q", t.text)
+		}
+		if left, err = p.eval(t, op, left, right); err != nil {
+			return exprValue{}, err
+		}
+	}
+}
+
+// parseProduct parses factors separated by "*", "x" or "/"
+func (p *exprParser) parseProduct() (exprValue, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return exprValue{}, err
+	}
+	for {
+		t := p.peek()
+		var op string
+		switch {
+		case isMultiplication(t):
+			op = "*"
+		case t.kind == exprOperator && t.text == "/":
+			op = "/"
+		default:
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return exprValue{}, err
+		}
+		if left, err = p.eval(t, op, left, right); err != nil {
+			return exprValue{}, err
+		}
+	}
+}
+
+// parseUnary parses an optionally negated or positive primary expression
+func (p *exprParser) parseUnary() (exprValue, error) {
+	t := p.peek()
+	if t.kind == exprOperator && (t.text == "-" || t.text == "+") {
+		p.next()
+		v, err := p.parseUnary()
+		if err != nil || t.text == "+" {
+			return v, err
+		}
+		return p.eval(t, "*", v, exprValue{scalar: -1})
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses a size, a scalar, or a parenthesized expression
+func (p *exprParser) parsePrimary() (exprValue, error) {
+	t := p.next()
+	switch t.kind {
+	case exprNumber:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return exprValue{}, &ParseError{p.input, t.offset, ComponentNumber, err.(*strconv.NumError).Err}
+		}
+		if w := p.peek(); w.kind == exprWord && !isMultiplication(w) {
+			p.next()
+			u, err := Parse(t.text + " " + w.text)
+			if pe, ok := err.(*ParseError); ok {
+				return exprValue{}, &ParseError{p.input, w.offset, pe.Component, pe.Err}
+			} else if err != nil {
+				return exprValue{}, p.errorf(w, "%w", err)
+			}
+			return exprValue{unit: u}, nil
+		}
+		return exprValue{scalar: f}, nil
+	case exprLeftParen:
+		v, err := p.parseSum()
+		if err != nil {
+			return exprValue{}, err
+		}
+		if r := p.next(); r.kind != exprRightParen {
+			return exprValue{}, p.errorf(r, "expected \")\" but found %q", r.text)
+		}
+		return v, nil
+	}
+	return exprValue{}, p.errorf(t, "unexpected %q", t.text)
+}
+
+// eval applies a binary operator to two values
+func (p *exprParser) eval(t exprToken, op string, l, r exprValue) (exprValue, error) {
+	var (
+		u   Unit
+		f   float64
+		err error
+	)
+	switch {
+	case op == "+" && l.isUnit() && r.isUnit():
+		u, err = AddUnits(l.unit, r.unit)
+	case op == "-" && l.isUnit() && r.isUnit():
+		u, err = SubtractUnits(l.unit, r.unit)
+	case op == "+" && !l.isUnit() && !r.isUnit():
+		return exprValue{scalar: l.scalar + r.scalar}, nil
+	case op == "-" && !l.isUnit() && !r.isUnit():
+		return exprValue{scalar: l.scalar - r.scalar}, nil
+	case op == "*" && l.isUnit() && !r.isUnit():
+		u, err = MultiplyUnit(l.unit, r.scalar)
+	case op == "*" && !l.isUnit() && r.isUnit():
+		u, err = MultiplyUnit(r.unit, l.scalar)
+	case op == "*" && !l.isUnit() && !r.isUnit():
+		return exprValue{scalar: l.scalar * r.scalar}, nil
+	case op == "/" && l.isUnit() && !r.isUnit():
+		u, err = DivideUnit(l.unit, r.scalar)
+	case op == "/" && l.isUnit() && r.isUnit():
+		f, err = DivideUnits(l.unit, r.unit)
+	case op == "/" && !l.isUnit() && !r.isUnit():
+		if r.scalar == 0 {
+			return exprValue{}, p.errorf(t, "%w", ErrUnitDivideByZero)
+		}
+		return exprValue{scalar: l.scalar / r.scalar}, nil
+	default:
+		return exprValue{}, p.errorf(t, "cannot apply %q to %s and %s", t.text, describeExprValue(l), describeExprValue(r))
+	}
+	if err != nil {
+		return exprValue{}, p.errorf(t, "%w", err)
+	}
+	return exprValue{unit: u, scalar: f}, nil
+}
+
+// describeExprValue describes a value for error messages
+func describeExprValue(v exprValue) string {
+	if v.isUnit() {
+		return "a unit"
+	}
+	return "a scalar"
+}
@@ -0,0 +1,110 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleParseExpr() {
+	for _, s := range []string{"1 GiB 512 MiB", "3x 4TB", "2 * 4 GiB + 512 MiB", "(10 GB - 2 GB) / 4"} {
+		u, _ := ParseExpr(s)
+		fmt.Println(u)
+	}
+	_, err := ParseExpr("2 GiB + 3")
+	fmt.Println(err)
+	// Output:
+	// 1.5 GiB
+	// 12 TB
+	// 8.5 GiB
+	// 2 GB
+	// parsing "2 GiB + 3": invalid expression at offset 6: cannot apply "+" to a unit and a scalar
+}
+
+type testParseExpr struct {
+	input    string
+	expected Unit
+}
+
+func TestParseExpr(t *testing.T) {
+	tt := []testParseExpr{
+		{"1 GiB", &IECUnit{1, GiB, 3}},
+		{"1GiB512MiB", &IECUnit{1.5, GiB, 3}},
+		{"1 GiB 512 MiB 512 MiB", &IECUnit{2, GiB, 3}},
+		{"3x 4TB", &SIUnit{12, TB, 12}},
+		{"3 x 4TB", &SIUnit{12, TB, 12}},
+		{"4TB x 3", &SIUnit{12, TB, 12}},
+		{"4 TB * 3 / 2", &SIUnit{6, TB, 12}},
+		{"2 * 4 GiB + 100 MB", &IECUnit{8 + 1e8/(1<<30), GiB, 3}},
+		{"2 * (4 GiB - 1 GiB)", &IECUnit{6, GiB, 3}},
+		{"-1 GiB + 3 GiB", &IECUnit{2, GiB, 3}},
+		{"+1 GiB", &IECUnit{1, GiB, 3}},
+		{"(1 + 1) * 512 MiB", &IECUnit{1024, MiB, 2}},
+		{"1 GiB / (1 MiB / 512 KiB)", &IECUnit{0.5, GiB, 3}},
+		{"1 KB + 1 kB", &JEDECUnit{1 + 1000.0/1024, KB, 1}},
+	}
+	for _, test := range tt {
+		u, err := ParseExpr(test.input)
+		assert.NoError(t, err, test.input)
+		assert.Equal(t, test.expected, u, test.input)
+	}
+}
+
+type testParseExprError struct {
+	input     string
+	offset    int
+	component ParseComponent
+	message   string
+}
+
+func TestParseExpr_Errors(t *testing.T) {
+	tt := []testParseExprError{
+		{"", 0, ComponentExpression, "unexpected \"end of expression\""},
+		{"1 GiB +", 7, ComponentExpression, "unexpected \"end of expression\""},
+		{"1 GiB + 2", 6, ComponentExpression, "cannot apply \"+\" to a unit and a scalar"},
+		{"1 GiB 2", 6, ComponentExpression, "missing operator before \"2\""},
+		{"1 GiB * 2 GiB", 6, ComponentExpression, ""},
+		{"2 / 1 GiB", 2, ComponentExpression, "cannot apply \"/\" to a scalar and a unit"},
+		{"1 GiB / 0", 6, ComponentExpression, "unit division by zero"},
+		{"1 GiB / (2 - 2)", 6, ComponentExpression, "unit division by zero"},
+		{"1 GiB / (1 / 0)", 11, ComponentExpression, "unit division by zero"},
+		{"(1 GiB", 6, ComponentExpression, "expected \")\" but found \"end of expression\""},
+		{"1 GiB)", 5, ComponentExpression, "unexpected \")\""},
+		{"1 GiB % 2", 6, ComponentExpression, "unexpected \"%\""},
+		{"1 FooBar", 2, ComponentSymbol, "unit symbol not supported"},
+		{"1.2.3 GiB", 0, ComponentNumber, "invalid syntax"},
+		{"1.2.3", 0, ComponentNumber, "invalid syntax"},
+		{"2 * 3", 0, ComponentExpression, "expression has no unit"},
+	}
+	for _, test := range tt {
+		_, err := ParseExpr(test.input)
+		var pe *ParseError
+		if assert.True(t, errors.As(err, &pe), "%s: %v", test.input, err) {
+			assert.True(t, errors.Is(err, ErrUnitCouldNotBeParsed), test.input)
+			assert.Equal(t, test.input, pe.Input)
+			assert.Equal(t, test.offset, pe.Offset, test.input)
+			assert.Equal(t, test.component, pe.Component, test.input)
+			assert.Contains(t, pe.Err.Error(), test.message, test.input)
+		}
+	}
+	_, err := ParseExpr("1 GiB / 0")
+	assert.True(t, errors.Is(err, ErrUnitDivideByZero))
+}
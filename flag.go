@@ -0,0 +1,164 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import "flag"
+
+// SizeFlagOption configures the values a SizeFlag accepts
+type SizeFlagOption func(*SizeFlag)
+
+// WithStandards restricts a SizeFlag to Units of the given standards, i.e.
+// WithStandards(IEC) rejects "10GB" but accepts "10GiB"
+func WithStandards(stds ...UnitStandard) SizeFlagOption {
+	return func(f *SizeFlag) {
+		f.standards = append(f.standards, stds...)
+	}
+}
+
+// WithMinSize rejects values smaller than min, compared in bytes across
+// standards
+func WithMinSize(min Unit) SizeFlagOption {
+	return func(f *SizeFlag) {
+		f.min = min
+	}
+}
+
+// WithMaxSize rejects values larger than max, compared in bytes across
+// standards
+func WithMaxSize(max Unit) SizeFlagOption {
+	return func(f *SizeFlag) {
+		f.max = max
+	}
+}
+
+// SizeFlag is a flag.Value parsing sizes like "10GB" or "512MiB" with Parse
+// into a Size. Values are validated against the SizeFlagOptions when the
+// flag is set, so that invalid sizes are reported by the FlagSet itself. It
+// also has the Type method expected by pflag
+type SizeFlag struct {
+	size      *Size
+	standards []UnitStandard
+	min, max  Unit
+}
+
+// NewSizeFlag returns a SizeFlag storing its value in p, which is set to the
+// default value. The default value is not validated against the options
+func NewSizeFlag(p *Size, value Unit, opts ...SizeFlagOption) *SizeFlag {
+	f := &SizeFlag{size: p}
+	for _, opt := range opts {
+		opt(f)
+	}
+	f.size.Unit = value
+	return f
+}
+
+// SizeVar defines a size flag with the given name, default value, usage
+// string and options on the FlagSet (flag.CommandLine if nil). The argument p
+// points to a Size in which to store the value of the flag
+func SizeVar(fs *flag.FlagSet, p *Size, name string, value Unit, usage string, opts ...SizeFlagOption) {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	fs.Var(NewSizeFlag(p, value, opts...), name, usage)
+}
+
+// SizeFlagVar defines a size flag like SizeVar, returning the address of a
+// Size in which to store the value of the flag
+func SizeFlagVar(fs *flag.FlagSet, name string, value Unit, usage string, opts ...SizeFlagOption) *Size {
+	p := new(Size)
+	SizeVar(fs, p, name, value, usage, opts...)
+	return p
+}
+
+// String implements flag.Value, returning the current value or an empty
+// string if there is none
+func (f *SizeFlag) String() string {
+	if f == nil || f.size == nil {
+		return ""
+	}
+	return f.size.String()
+}
+
+// Set implements flag.Value, parsing s and validating it against the allowed
+// standards and bounds of the SizeFlag. Symbols supported by several standards
+// (like Byte, or GB of SI and JEDEC) are measured in the first allowed
+// standard supporting them
+func (f *SizeFlag) Set(s string) error {
+	u, err := f.parse(s)
+	if err != nil {
+		return err
+	}
+	if err := f.validate(u); err != nil {
+		return err
+	}
+	f.size.Unit = u
+	return nil
+}
+
+// parse parses s with ParseWithStandard for each allowed standard in turn,
+// or with Parse if none of them measures its symbol
+func (f *SizeFlag) parse(s string) (Unit, error) {
+	for _, std := range f.standards {
+		u, err := ParseWithStandard(s, std)
+		if err != nil {
+			return nil, err
+		}
+		if u.Standard() == std {
+			return u, nil
+		}
+	}
+	return Parse(s)
+}
+
+// validate checks a Unit against the options of the SizeFlag
+func (f *SizeFlag) validate(u Unit) error {
+	if len(f.standards) > 0 {
+		allowed := false
+		for _, std := range f.standards {
+			if u.Standard() == std {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return NewErrUnitStandardNotSupported(u.Standard())
+		}
+	}
+	if f.min != nil && Compare(u, f.min) < 0 {
+		return NewErrUnitBelowMinimum(u, f.min)
+	}
+	if f.max != nil && Compare(u, f.max) > 0 {
+		return NewErrUnitAboveMaximum(u, f.max)
+	}
+	return nil
+}
+
+// Type returns the name of the flag type, as used by pflag in usage messages
+func (f *SizeFlag) Type() string {
+	return "size"
+}
+
+// Get implements flag.Getter, returning the current Unit
+func (f *SizeFlag) Get() interface{} {
+	return f.size.Unit
+}
+
+// Unit returns the current Unit, which is nil if there is no default and the
+// flag was not set
+func (f *SizeFlag) Unit() Unit {
+	return f.size.Unit
+}
@@ -0,0 +1,121 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ flag.Getter = &SizeFlag{}
+
+func ExampleSizeVar() {
+	fs := flag.NewFlagSet("example", flag.ContinueOnError)
+	var maxSize Size
+	def, _ := NewSIUnit(1, GB)
+	SizeVar(fs, &maxSize, "max-size", def, "maximum upload size")
+	_ = fs.Parse([]string{"--max-size=10GB"})
+	fmt.Println(maxSize, maxSize.ByteSize())
+	// Output:
+	// 10 GB 1e+10
+}
+
+type testSizeFlagSet struct {
+	in       string
+	expected Unit
+	err      error
+}
+
+func TestSizeFlag_Set(t *testing.T) {
+	min, _ := NewIECUnit(1, MiB)
+	max, _ := NewSIUnit(1, TB)
+	tt := []testSizeFlagSet{
+		{"512MiB", &IECUnit{512, MiB, 2}, nil},
+		{"1 MiB", &IECUnit{1, MiB, 2}, nil},
+		{"1TB", &SIUnit{1, TB, 12}, nil},
+		{"2 MB", &SIUnit{2, MB, 6}, nil},
+		{"1 MB", nil, ErrUnitBelowMinimum},
+		{"1000 KiB", nil, ErrUnitBelowMinimum},
+		{"1 TiB", nil, ErrUnitAboveMaximum},
+		{"10 XB", nil, ErrUnitSymbolNotSupported},
+		{"ten MB", nil, strconv.ErrSyntax},
+	}
+	for _, test := range tt {
+		var s Size
+		f := NewSizeFlag(&s, nil, WithMinSize(min), WithMaxSize(max))
+		err := f.Set(test.in)
+		if test.err != nil {
+			assert.Error(t, err, test.in)
+			assert.Contains(t, err.Error(), test.err.Error(), test.in)
+			assert.Nil(t, s.Unit, test.in)
+			continue
+		}
+		assert.NoError(t, err, test.in)
+		assert.Equal(t, test.expected, s.Unit, test.in)
+		assert.Equal(t, test.expected, f.Get(), test.in)
+	}
+}
+
+func TestSizeFlag_WithStandards(t *testing.T) {
+	var s Size
+	def, _ := NewIECUnit(1, GiB)
+	f := NewSizeFlag(&s, def, WithStandards(IEC))
+	assert.Error(t, f.Set("10GB"))
+	assert.Equal(t, def, f.Unit())
+	assert.NoError(t, f.Set("10GiB"))
+	assert.Equal(t, &IECUnit{10, GiB, 3}, f.Unit())
+	assert.Equal(t, "10 GiB", f.String())
+	assert.Equal(t, "size", f.Type())
+	// Bit and Byte are measured in the allowed standard
+	assert.NoError(t, f.Set("512 Byte"))
+	assert.Equal(t, &IECUnit{512, Byte, 0}, f.Unit())
+	assert.NoError(t, f.Set("8 Bit"))
+	assert.Equal(t, &IECUnit{8, Bit, 0}, f.Unit())
+	assert.Error(t, f.Set("512 Byte (SI)"))
+
+	f = NewSizeFlag(&s, def, WithStandards(IEC, JEDEC))
+	assert.NoError(t, f.Set("16 GB"))
+	assert.Equal(t, &JEDECUnit{16, GB, 3}, f.Unit())
+	assert.Error(t, f.Set("16 kB"))
+}
+
+func TestSizeVar(t *testing.T) {
+	var out bytes.Buffer
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&out)
+	def, _ := NewIECUnit(64, MiB)
+	cache := SizeFlagVar(fs, "cache-size", def, "cache size", WithMaxSize(&IECUnit{1, GiB, 3}))
+	var empty Size
+	SizeVar(fs, &empty, "quota", nil, "quota")
+	assert.Equal(t, def, cache.Unit)
+	assert.NoError(t, fs.Parse(nil))
+	assert.Equal(t, def, cache.Unit)
+	assert.Nil(t, empty.Unit)
+	assert.NoError(t, fs.Parse([]string{"-cache-size", "128MiB", "-quota=5 GB"}))
+	assert.Equal(t, &IECUnit{128, MiB, 2}, cache.Unit)
+	assert.Equal(t, &SIUnit{5, GB, 9}, empty.Unit)
+	assert.Error(t, fs.Parse([]string{"-cache-size=2GiB"}))
+	assert.Contains(t, out.String(), ErrUnitAboveMaximum.Error())
+	out.Reset()
+	fs.PrintDefaults()
+	assert.Contains(t, out.String(), "(default 64 MiB)")
+}
@@ -0,0 +1,116 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// formatUnit formats a Unit for a verb, precision, and long name flag
+func formatUnit(u Unit, verb rune, prec int, hasPrec, long bool) (string, bool) {
+	switch verb {
+	case 'v', 's':
+	case 'h':
+		u = Normalize(u)
+	case 'd':
+		return formatByteCount(u), true
+	default:
+		return "", false
+	}
+	var size string
+	if b, ok := u.(*BigUnit); ok {
+		if !hasPrec {
+			prec, hasPrec = ratDecimalPlaces(b.Rat())
+		}
+		if hasPrec {
+			size = b.Rat().FloatString(prec)
+		} else {
+			size = strconv.FormatFloat(u.Size(), 'f', -1, 64)
+		}
+	} else if hasPrec {
+		size = strconv.FormatFloat(u.Size(), 'f', prec, 64)
+	} else {
+		size = strconv.FormatFloat(u.Size(), 'f', -1, 64)
+	}
+	if !long {
+		return size + " " + string(u.Symbol()), true
+	}
+	name, ok := unitSymbolNames[u.Symbol()]
+	if !ok {
+		return size + " " + string(u.Symbol()), true
+	}
+	if size != "1" && size != "-1" {
+		name += "s"
+	}
+	return size + " " + name, true
+}
+
+// ratDecimalPlaces returns the number of decimal places needed to format r
+// exactly, or false if r has no finite decimal form, i.e. 1/3
+func ratDecimalPlaces(r *big.Rat) (int, bool) {
+	d := new(big.Int).Set(r.Denom())
+	q, m := new(big.Int), new(big.Int)
+	places := func(p int64) int {
+		n, f := 0, big.NewInt(p)
+		for q.DivMod(d, f, m); m.Sign() == 0; q.DivMod(d, f, m) {
+			d.Set(q)
+			n++
+		}
+		return n
+	}
+	twos, fives := places(2), places(5)
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+	if twos > fives {
+		return twos, true
+	}
+	return fives, true
+}
+
+// formatByteCount formats the size of a Unit measured in bytes, rounded to the
+// nearest byte
+func formatByteCount(u Unit) string {
+	if b, err := UnitToBytes(u); err == nil {
+		return strconv.FormatInt(int64(b), 10)
+	}
+	r := byteRat(u)
+	return r.FloatString(0)
+}
+
+// formatState writes a Unit to a fmt.State for a verb, honoring the precision,
+// width, and flags of the State
+func formatState(f fmt.State, verb rune, u Unit) {
+	prec, hasPrec := f.Precision()
+	s, ok := formatUnit(u, verb, prec, hasPrec, f.Flag('+'))
+	if !ok {
+		fmt.Fprintf(f, "%%!%c(%T=%s)", verb, u, u)
+		return
+	}
+	if w, ok := f.Width(); ok && len(s) < w {
+		pad := strings.Repeat(" ", w-len(s))
+		if f.Flag('-') {
+			s += pad
+		} else {
+			s = pad + s
+		}
+	}
+	fmt.Fprint(f, s)
+}
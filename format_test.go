@@ -0,0 +1,95 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleIECUnit_Format() {
+	a, _ := NewIECUnit(1536, MiB)
+	fmt.Printf("%v\n", a)
+	fmt.Printf("%h\n", a)
+	fmt.Printf("%.2h\n", a)
+	fmt.Printf("%+h\n", a)
+	fmt.Printf("%d\n", a)
+	// Output:
+	// 1536 MiB
+	// 1.5 GiB
+	// 1.50 GiB
+	// 1.5 gibibytes
+	// 1610612736
+}
+
+type testFormat struct {
+	format   string
+	unit     Unit
+	expected string
+}
+
+func TestFormat(t *testing.T) {
+	third, _ := NewBigUnit(IEC, big.NewRat(1, 3), YiB)
+	bytes, _ := NewBigUnit(IEC, new(big.Rat).SetInt64(1<<60-1), Byte)
+	eib, _ := newBigUnitFromBytes(IEC, EiB, bytes.ByteRat())
+	tt := []testFormat{
+		{"%v", &IECUnit{1.5, GiB, 3}, "1.5 GiB"},
+		{"%s", &SIUnit{1.5, GB, 9}, "1.5 GB"},
+		{"%.2v", &IECUnit{1.5, GiB, 3}, "1.50 GiB"},
+		{"%.0s", &SIUnit{1.5, GB, 9}, "2 GB"},
+		{"%+v", &IECUnit{1.5, GiB, 3}, "1.5 gibibytes"},
+		{"%+v", &IECUnit{1, GiB, 3}, "1 gibibyte"},
+		{"%+v", &SIUnit{-1, Mb, 6}, "-1 megabit"},
+		{"%+.1v", &SIUnit{1, kB, 3}, "1.0 kilobytes"},
+		{"%+v", &SIUnit{1, UnitSymbol("FooBar"), 30}, "1 FooBar"},
+		{"%h", &SIUnit{1500, kB, 3}, "1.5 MB"},
+		{"%h", &SIUnit{0.5, kB, 3}, "5 hB"},
+		{"%h", &SIUnit{-2000, Mb, 6}, "-2 Gb"},
+		{"%h", &IECUnit{0.25, Byte, 0}, "0.25 Byte"},
+		{"%h", &IECUnit{0, TiB, 4}, "0 Byte"},
+		{"%h", &IECUnit{2048, Kib, 1}, "2 Mib"},
+		{"%h", &IECUnit{1, UnitSymbol("FooBar"), 30}, "1 FooBar"},
+		{"%d", &SIUnit{1.5, kB, 3}, "1500"},
+		{"%d", &IECUnit{1, Kib, 1}, "128"},
+		{"%d", &IECUnit{1, YiB, 8}, "1208925819614629174706176"},
+		{"%12v", &IECUnit{1, KiB, 1}, "       1 KiB"},
+		{"%-12v|", &IECUnit{1, KiB, 1}, "1 KiB       |"},
+		{"%x", &IECUnit{1, KiB, 1}, "%!x(*bitty.IECUnit=1 KiB)"},
+		{"%v", third, "0.3333333333333333 YiB"},
+		{"%.30v", third, "0.333333333333333333333333333333 YiB"},
+		{"%.1h", third, "341.3 ZiB"},
+		{"%v", bytes, "1152921504606846975 Byte"},
+		{"%s", eib, "0.999999999999999999132638262011596452794037759304046630859375 EiB"},
+		{"%.3v", eib, "1.000 EiB"},
+	}
+	for _, test := range tt {
+		assert.Equal(t, test.expected, fmt.Sprintf(test.format, test.unit), test.format)
+	}
+}
+
+func TestString(t *testing.T) {
+	var _ fmt.Stringer = &IECUnit{}
+	var _ fmt.Stringer = &SIUnit{}
+	var _ fmt.Stringer = &BigUnit{}
+	assert.Equal(t, "10 Mib", (&IECUnit{10, Mib, 2}).String())
+	assert.Equal(t, "0.001 TB", (&SIUnit{0.001, TB, 12}).String())
+	b, _ := NewBigUnit(IEC, new(big.Rat).SetInt64(1<<60-1), Byte)
+	assert.Equal(t, "1152921504606846975 Byte", b.String(), "BigUnit sizes are exact")
+}
@@ -17,7 +17,9 @@ package bitty
 */
 
 import (
+	"fmt"
 	"math"
+	"time"
 )
 
 var iecUnitExponentMap = map[UnitSymbol]int{
@@ -39,6 +41,10 @@ var iecUnitExponentMap = map[UnitSymbol]int{
 	ZiB:  7,
 	Yib:  8,
 	YiB:  8,
+	Rib:  9,
+	RiB:  9,
+	Qib:  10,
+	QiB:  10,
 }
 
 // IECUnitSymbolPair represents a base 2 binary unit symbol pair as defined by the 9th
@@ -113,16 +119,7 @@ func (u *IECUnit) Size() float64 {
 
 // BitSize returns the size of the Unit measured in bits
 func (u *IECUnit) BitSize() float64 {
-	switch u.Symbol() {
-	case Bit:
-		return float64(u.ByteSize() / 8)
-	case Byte,
-		Kib, Mib, Gib, Tib, Pib, Eib, Zib, Yib,
-		KiB, MiB, GiB, TiB, PiB, EiB, ZiB, YiB:
-		return float64(u.ByteSize() * 8)
-	default:
-		return float64(0)
-	}
+	return u.ByteSize() * 8
 }
 
 // ByteSize returns the size of the Unit measured in bytes
@@ -132,94 +129,117 @@ func (u *IECUnit) ByteSize() float64 {
 
 // SizeInUnit returns the size of the Unit measured in an arbitrary UnitSymbol from Bit up to YiB or YB
 func (u *IECUnit) SizeInUnit(symbol UnitSymbol) float64 {
-	_, uok := FindUnitSymbolPairBySymbol(IEC, u.Symbol())
-	p, ok := FindUnitSymbolPairBySymbol(IEC, symbol)
-	if uok && ok {
-		var (
-			left    = u.ByteSize()
-			right   = UnitSymbolToByteSize(IEC, symbol, u.Size())
-			diffExp = float64(u.Exponent()) - float64(p.Exponent())
-		)
-		if diffExp > 0 {
-			return right * diffExp
-		}
-		if left > 0.0 && right > 0.0 {
-			return float64((left / right) * u.Size())
-		}
+	std, ok := findStandardForSymbol(IEC, symbol)
+	if !ok {
+		return float64(0)
 	}
-	return float64(0)
+	return BytesToUnitSymbolSize(std, symbol, u.ByteSize())
+}
+
+// String returns the size and symbol of a IECUnit, i.e. "1.5 GiB"
+func (u *IECUnit) String() string {
+	s, _ := formatUnit(u, 'v', 0, false, false)
+	return s
+}
+
+// Format implements fmt.Formatter, supporting the verbs and flags described in
+// the package documentation
+func (u *IECUnit) Format(f fmt.State, verb rune) {
+	formatState(f, verb, u)
 }
 
 // Add attempts to add one Unit to another
 func (u *IECUnit) Add(unit Unit) Unit {
-	var (
-		nexp     int
-		nsym     UnitSymbol
-		size     float64
-		lok, rok bool
-	)
 	// Validate both sides for valid symbols
-	lok, rok = ValidateSymbols(u.Symbol(), unit.Symbol())
-	if !lok && !rok {
-		nu, _ := NewIECUnit(0, Byte)
-		return nu
-	}
+	lok, rok := ValidateSymbols(u.Symbol(), unit.Symbol())
 	if lok && !rok {
 		return u
 	}
 	if rok && !lok {
 		return unit
 	}
-	// Lets get adding
-	left := u.ByteSize()
-	right := unit.ByteSize()
-	total := left + right
-	if total > 0 {
-		nexp = int(math.Round(math.Log2(total) / 10))
+	nu, err := u.AddE(unit)
+	if err != nil {
+		nu, _ = NewIECUnit(0, Byte)
+	}
+	return nu
+}
+
+// AddE attempts to add one Unit to another, returning an error if either
+// symbol is invalid or the sum cannot be represented
+func (u *IECUnit) AddE(unit Unit) (Unit, error) {
+	var (
+		nexp int
+		nsym UnitSymbol
+		size float64
+	)
+	if err := checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
 	}
-	if u.Exponent() >= unit.Exponent() {
+	// Lets get adding
+	total := u.ByteSize() + unit.ByteSize()
+	switch {
+	case unit.Standard() != IEC:
+		// The exponent of another standard may not exist in this one, so the
+		// sum is measured by the greatest symbol not exceeding it
+		if abs := math.Abs(total); abs >= 1 {
+			nexp = int(math.Floor(math.Log2(abs) / 10))
+		}
+	case u.Exponent() >= unit.Exponent():
 		nexp = u.Exponent()
-	} else {
+	default:
 		nexp = unit.Exponent()
 	}
-	lsym, ok := FindLeastUnitSymbol(IEC, nexp)
-	gsym, ok := FindGreatestUnitSymbol(IEC, nexp)
+	pair, ok := FindFloorUnitSymbolPair(IEC, nexp)
 	if !ok {
-		nu, _ := NewIECUnit(0, Byte)
-		return nu
+		return nil, NewErrUnitExponentNotSupported(nexp)
 	}
+	lsym, gsym := pair.Least(), pair.Greatest()
 	smallSize := BytesToUnitSymbolSize(IEC, lsym, total)
 	lrgSize := BytesToUnitSymbolSize(IEC, gsym, total)
-	if lrgSize < 1 {
+	if math.Abs(lrgSize) < 1 {
 		nsym = lsym
 		size = smallSize
 	} else {
 		nsym = gsym
 		size = lrgSize
 	}
-	nu, _ := NewIECUnit(size, nsym)
-	return nu
+	nu, err := NewIECUnit(size, nsym)
+	if err != nil {
+		return nil, err
+	}
+	return nu, nil
 }
 
 // Subtract attempts to subtract one Unit from another
 func (u *IECUnit) Subtract(unit Unit) Unit {
-	var (
-		ok, lok, rok, neg bool
-		total             float64
-		nexp              int
-		lsym, gsym        UnitSymbol
-	)
-	lok, rok = ValidateSymbols(u.Symbol(), unit.Symbol())
-	if !lok && !rok {
-		nu, _ := NewIECUnit(0, Byte)
-		return nu
-	}
+	lok, rok := ValidateSymbols(u.Symbol(), unit.Symbol())
 	if lok && !rok {
 		return u
 	}
 	if rok && !lok {
 		return unit
 	}
+	nu, err := u.SubtractE(unit)
+	if err != nil {
+		nu, _ = NewIECUnit(0, Byte)
+	}
+	return nu
+}
+
+// SubtractE attempts to subtract one Unit from another, returning an error if
+// either symbol is invalid or the difference cannot be represented
+func (u *IECUnit) SubtractE(unit Unit) (Unit, error) {
+	var (
+		neg   bool
+		total float64
+		nexp  int
+		nu    *IECUnit
+		err   error
+	)
+	if err = checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
+	}
 	left := u.ByteSize()
 	right := unit.ByteSize()
 	if left >= right {
@@ -231,40 +251,91 @@ func (u *IECUnit) Subtract(unit Unit) Unit {
 	if total > 0 {
 		nexp = int(math.Round(math.Log2(total) / 10))
 	}
-	lsym, ok = FindLeastUnitSymbol(IEC, nexp)
-	gsym, ok = FindGreatestUnitSymbol(IEC, nexp)
+	// Differences of less than a byte are measured in bytes
+	if nexp < 0 {
+		nexp = 0
+	}
+	pair, ok := FindFloorUnitSymbolPair(IEC, nexp)
 	if !ok {
-		nu, _ := NewIECUnit(0, Byte)
-		return nu
+		return nil, NewErrUnitExponentNotSupported(nexp)
 	}
+	lsym, gsym := pair.Least(), pair.Greatest()
 	smlSize := BytesToUnitSymbolSize(IEC, lsym, total)
 	lrgSize := BytesToUnitSymbolSize(IEC, gsym, total)
 	if lrgSize >= 0 {
 		if neg {
 			lrgSize = -lrgSize
 		}
-		nu, err := NewIECUnit(lrgSize, gsym)
-		if err != nil {
-			return u
+		nu, err = NewIECUnit(lrgSize, gsym)
+	} else {
+		if neg {
+			smlSize = -smlSize
 		}
-		return nu
-	}
-	if neg {
-		smlSize = -smlSize
+		nu, err = NewIECUnit(smlSize, lsym)
 	}
-	nu, err := NewIECUnit(smlSize, lsym)
 	if err != nil {
-		return u
+		return nil, err
 	}
-	return nu
+	return nu, nil
 }
 
-// Multiply attempts to multiply one Unit by another
+// Multiply attempts to multiply one Unit by another. As the product of two
+// Units has no meaningful size, a 0 Byte Unit is returned.
+//
+// Deprecated: use MultiplyE, which reports ErrUnitMultiplyNotSupported, or
+// Scale in order to multiply by a scalar
 func (u *IECUnit) Multiply(unit Unit) Unit {
-	return nil
+	nu, _ := NewIECUnit(0, Byte)
+	return nu
 }
 
-// Divide attempts to divide one Unit by another
+// Divide attempts to divide one Unit by another. As the quotient of two Units
+// is dimensionless, a 0 Byte Unit is returned.
+//
+// Deprecated: use DivideE, which reports ErrUnitDivideNotSupported, Ratio in
+// order to divide by a Unit, or Scale in order to divide by a scalar
 func (u *IECUnit) Divide(unit Unit) Unit {
-	return nil
+	nu, _ := NewIECUnit(0, Byte)
+	return nu
+}
+
+// Scale returns a new IECUnit with the same symbol and the size multiplied by n
+func (u *IECUnit) Scale(n float64) Unit {
+	return &IECUnit{u.size * n, u.symbol, u.exponent}
+}
+
+// MultiplyE returns an error, as the product of two Units has no meaningful
+// size; use Scale in order to multiply by a scalar
+func (u *IECUnit) MultiplyE(unit Unit) (Unit, error) {
+	if err := checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
+	}
+	return nil, NewErrUnitMultiplyNotSupported(u.Symbol(), unit.Symbol())
+}
+
+// DivideE returns an error, as the quotient of two Units is dimensionless; use
+// Ratio in order to divide by a Unit, or Scale in order to divide by a scalar
+func (u *IECUnit) DivideE(unit Unit) (Unit, error) {
+	if err := checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
+	}
+	return nil, NewErrUnitDivideNotSupported(u.Symbol(), unit.Symbol())
+}
+
+// Ratio returns the dimensionless ratio of the IECUnit to another Unit
+func (u *IECUnit) Ratio(unit Unit) (float64, error) {
+	return DivideUnits(u, unit)
+}
+
+// Over returns the Rate of the IECUnit transferred over a duration, i.e. the
+// IECUnit per d, or the zero Rate if d is not positive, as by NewRate
+func (u *IECUnit) Over(d time.Duration) Rate {
+	r, _ := NewRate(u, d)
+	return r
+}
+
+// TransferTime returns the time taken to transfer the IECUnit at a Rate, or the
+// maximum time.Duration if the Rate is not positive
+func (u *IECUnit) TransferTime(r Rate) time.Duration {
+	return transferTime(u, r)
 }
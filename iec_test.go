@@ -17,8 +17,10 @@ package bitty
 */
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"math/rand"
 	"testing"
 	"time"
@@ -41,8 +43,8 @@ func ExampleNewIECUnit() {
 	fmt.Printf("%v\n", cerr)
 	fmt.Printf("%v\n", derr)
 	// Output:
-	// &{10 Mib 2}
-	// &{1 GiB 3}
+	// 10 Mib
+	// 1 GiB
 	// unit symbol not supported: empty symbol
 	// unit symbol not supported: fooBar
 }
@@ -67,7 +69,7 @@ func generateTestIECUnitByteSize(t *testing.T, sym UnitSymbol) testIECUnit {
 	lb := float64(math.Exp2(le) * l.Unit.size)
 	switch sym {
 	case Bit:
-		l.Expected = l.Unit.size * 8
+		l.Expected = l.Unit.size * 0.125
 	case Byte:
 		l.Expected = l.Unit.size
 	case Kib, Mib, Gib, Tib, Pib, Eib, Zib, Yib:
@@ -461,3 +463,123 @@ func TestIECUnit_Subtract(t *testing.T) {
 		assert.Equal(t, tst.expected, u)
 	}
 }
+
+func ExampleIECUnit_Scale() {
+	a, _ := NewIECUnit(4, GiB)
+	b := a.Scale(3)
+	c := a.Scale(0.5)
+	fmt.Printf("%.f %s\n", b.Size(), b.Symbol())
+	fmt.Printf("%.f %s\n", c.Size(), c.Symbol())
+	// Output:
+	// 12 GiB
+	// 2 GiB
+}
+
+func ExampleIECUnit_Ratio() {
+	a, _ := NewIECUnit(1, GiB)
+	b, _ := NewIECUnit(512, MiB)
+	r, _ := a.Ratio(b)
+	fmt.Println(r)
+	// Output:
+	// 2
+}
+
+func TestIECUnit_MultiplyDivide(t *testing.T) {
+	a, _ := NewIECUnit(4, GiB)
+	b, _ := NewIECUnit(2, MiB)
+	zero, _ := NewIECUnit(0, Byte)
+	assert.Equal(t, zero, a.Multiply(b))
+	assert.Equal(t, zero, a.Divide(b))
+	assert.Equal(t, &IECUnit{4, GiB, 3}, a, "receiver is not modified")
+}
+
+func TestCalculator_MultiplyDivide(t *testing.T) {
+	bu, _ := NewBigUnit(SI, big.NewRat(3, 2), GB)
+	for _, u := range []Unit{&IECUnit{4, GiB, 3}, &SIUnit{4, GB, 9}, &JEDECUnit{4, GB, 3}, bu} {
+		var c Calculator = u
+		for _, r := range []Unit{c.Multiply(u), c.Divide(u)} {
+			if assert.NotNil(t, r, "%v", u) {
+				assert.Equal(t, float64(0), r.Size(), "%v", u)
+				assert.Equal(t, Byte, r.Symbol(), "%v", u)
+				assert.Equal(t, u.Standard(), r.Standard(), "%v", u)
+			}
+		}
+		_, err := u.(CheckedCalculator).MultiplyE(u)
+		assert.True(t, errors.Is(err, ErrUnitMultiplyNotSupported), "%v", u)
+		_, err = u.(CheckedCalculator).DivideE(u)
+		assert.True(t, errors.Is(err, ErrUnitDivideNotSupported), "%v", u)
+	}
+}
+
+func TestIECUnit_Ratio(t *testing.T) {
+	a, _ := NewIECUnit(4, GiB)
+	zero, _ := NewIECUnit(0, Byte)
+	bu := &IECUnit{1, UnitSymbol("FooBar"), 30}
+	r, err := a.Ratio(a)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), r)
+	_, err = a.Ratio(zero)
+	assert.True(t, errors.Is(err, ErrUnitDivideByZero))
+	_, err = a.Ratio(bu)
+	assert.Error(t, err)
+}
+
+func ExampleIECUnit_AddE() {
+	a, _ := NewIECUnit(2, MiB)
+	b, _ := NewIECUnit(512, KiB)
+	c, err := a.AddE(b)
+	fmt.Println(c.Size(), c.Symbol(), err)
+	_, err = a.AddE(&IECUnit{1, UnitSymbol("FooBar"), 30})
+	fmt.Println(err)
+	// Output:
+	// 2.5 MiB <nil>
+	// unit symbol not supported: FooBar
+}
+
+func TestUnit_AddE_CrossStandard(t *testing.T) {
+	tt := []struct {
+		left, right Unit
+		expected    Unit
+	}{
+		{&IECUnit{1, Byte, 0}, &SIUnit{1, TB, 12}, &IECUnit{(1e12 + 1) / 1073741824, GiB, 3}},
+		{&IECUnit{1, GiB, 3}, &SIUnit{1, TB, 12}, &IECUnit{(1073741824 + 1e12) / 1073741824, GiB, 3}},
+		{&JEDECUnit{1, GB, 3}, &IECUnit{1, PiB, 5}, &JEDECUnit{1024.0009765625, TB, 4}},
+		{&SIUnit{1, kB, 3}, &IECUnit{1, KiB, 1}, &SIUnit{2.024, kB, 3}},
+		{&SIUnit{1, kB, 3}, &IECUnit{-1, KiB, 1}, &SIUnit{-24, Byte, 0}},
+	}
+	for _, test := range tt {
+		u, err := test.left.(CheckedCalculator).AddE(test.right)
+		assert.NoError(t, err, "%v + %v", test.left, test.right)
+		assert.True(t, Equal(test.expected, u), "%v + %v = %v", test.left, test.right, u)
+		assert.Equal(t, test.expected.Symbol(), u.Symbol(), "%v + %v", test.left, test.right)
+		assert.True(t, Equal(test.expected, test.left.Add(test.right)), "%v + %v", test.left, test.right)
+	}
+}
+
+func TestIECUnit_CheckedCalculator(t *testing.T) {
+	var _ CheckedCalculator = &IECUnit{}
+	var (
+		a, _ = NewIECUnit(10, GiB)
+		b, _ = NewIECUnit(10.25, GiB)
+		bu   = &IECUnit{1, UnitSymbol("FooBar"), 30}
+	)
+	u, err := a.SubtractE(b)
+	assert.NoError(t, err)
+	assert.Equal(t, &IECUnit{-0.25, GiB, 3}, u)
+	// Differences of less than a byte are measured in bytes
+	u, err = (&IECUnit{1, Bit, 0}).SubtractE(&IECUnit{0.99, Bit, 0})
+	assert.NoError(t, err)
+	assert.Equal(t, Byte, u.Symbol())
+	assert.InDelta(t, 0.01, u.BitSize(), 1e-9)
+	for _, f := range []func(Unit) (Unit, error){a.AddE, a.SubtractE, a.MultiplyE, a.DivideE, bu.AddE} {
+		u, err = f(bu)
+		assert.Nil(t, u)
+		assert.EqualError(t, err, "unit symbol not supported: FooBar")
+	}
+	u, err = a.MultiplyE(b)
+	assert.Nil(t, u)
+	assert.EqualError(t, err, "unit multiplication by unit not supported: GiB * GiB")
+	u, err = a.DivideE(b)
+	assert.Nil(t, u)
+	assert.EqualError(t, err, "unit division by unit not supported: GiB / GiB")
+}
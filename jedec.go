@@ -0,0 +1,331 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+var jedecUnitExponentMap = map[UnitSymbol]int{
+	Bit:  0,
+	Byte: 0,
+	Kb:   1,
+	KB:   1,
+	Mb:   2,
+	MB:   2,
+	Gb:   3,
+	GB:   3,
+	Tb:   4,
+	TB:   4,
+}
+
+// JEDECUnitSymbolPair represents a base 2 binary unit symbol pair as defined by
+// JEDEC 100B.01, where the decimal prefixes K, M and G (and commonly T) denote
+// powers of 1024
+type JEDECUnitSymbolPair struct {
+	least, greatest UnitSymbol
+	exponent        int
+}
+
+// NewJEDECUnitSymbolPair takes a UnitStandard and returns a new UnitSymbolPair
+func NewJEDECUnitSymbolPair(l, r UnitSymbol, e int) UnitSymbolPair {
+	return &JEDECUnitSymbolPair{least: l, greatest: r, exponent: e}
+}
+
+// Standard returns the UnitStandard of a JEDECUnitSymbolPair: JEDEC
+func (pair *JEDECUnitSymbolPair) Standard() UnitStandard {
+	return JEDEC
+}
+
+// Exponent returns the exponent of a JEDECUnitSymbolPair
+func (pair *JEDECUnitSymbolPair) Exponent() int {
+	return pair.exponent
+}
+
+// Least returns the least UnitSymbol of a JEDECUnitSymbolPair
+func (pair *JEDECUnitSymbolPair) Least() UnitSymbol {
+	return pair.least
+}
+
+// Greatest returns the greatest UnitSymbol of a JEDECUnitSymbolPair
+func (pair *JEDECUnitSymbolPair) Greatest() UnitSymbol {
+	return pair.greatest
+}
+
+// JEDECUnit handles binary units as dictated by JEDEC 100B.01, as used by
+// memory vendors and some operating systems, i.e. 1 KB is 1024 bytes
+type JEDECUnit struct {
+	// size is the size as measured by the symbol (UnitSymbol), which is equivalent to:
+	// 		b(2^10)^n(1/8)
+	// 		B(2^10)^n
+	size     float64
+	symbol   UnitSymbol
+	exponent int
+}
+
+// NewJEDECUnit returns a *JEDECUnit with the proper exponent included
+func NewJEDECUnit(size float64, sym UnitSymbol) (*JEDECUnit, error) {
+	if pair, ok := FindUnitSymbolPairBySymbol(JEDEC, sym); ok {
+		return &JEDECUnit{size, sym, pair.Exponent()}, nil
+	}
+	return nil, NewErrUnitSymbolNotSupported(sym)
+}
+
+// Standard returns the UnitStandard of a JEDECUnit: JEDEC
+func (u *JEDECUnit) Standard() UnitStandard {
+	return JEDEC
+}
+
+// Exponent returns the exponent of a JEDECUnit
+func (u *JEDECUnit) Exponent() int {
+	return u.exponent
+}
+
+// Symbol returns the UnitSymbol of a JEDECUnit
+func (u *JEDECUnit) Symbol() UnitSymbol {
+	return u.symbol
+}
+
+// Size returns the size of a JEDECUnit
+func (u *JEDECUnit) Size() float64 {
+	return u.size
+}
+
+// BitSize returns the size of the Unit measured in bits
+func (u *JEDECUnit) BitSize() float64 {
+	return u.ByteSize() * 8
+}
+
+// ByteSize returns the size of the Unit measured in bytes
+func (u *JEDECUnit) ByteSize() float64 {
+	return UnitSymbolToByteSize(JEDEC, u.Symbol(), u.Size())
+}
+
+// SizeInUnit returns the size of the Unit measured in an arbitrary UnitSymbol from Bit up to YiB or YB
+func (u *JEDECUnit) SizeInUnit(symbol UnitSymbol) float64 {
+	std, ok := findStandardForSymbol(JEDEC, symbol)
+	if !ok {
+		return float64(0)
+	}
+	return BytesToUnitSymbolSize(std, symbol, u.ByteSize())
+}
+
+// String returns the size and symbol of a JEDECUnit, i.e. "1.5 GB"
+func (u *JEDECUnit) String() string {
+	s, _ := formatUnit(u, 'v', 0, false, false)
+	return s
+}
+
+// Format implements fmt.Formatter, supporting the verbs and flags described in
+// the package documentation
+func (u *JEDECUnit) Format(f fmt.State, verb rune) {
+	formatState(f, verb, u)
+}
+
+// Add attempts to add one Unit to another
+func (u *JEDECUnit) Add(unit Unit) Unit {
+	// Validate both sides for valid symbols
+	lok, rok := ValidateSymbols(u.Symbol(), unit.Symbol())
+	if lok && !rok {
+		return u
+	}
+	if rok && !lok {
+		return unit
+	}
+	nu, err := u.AddE(unit)
+	if err != nil {
+		nu, _ = NewJEDECUnit(0, Byte)
+	}
+	return nu
+}
+
+// AddE attempts to add one Unit to another, returning an error if either
+// symbol is invalid or the sum cannot be represented
+func (u *JEDECUnit) AddE(unit Unit) (Unit, error) {
+	var (
+		nexp int
+		nsym UnitSymbol
+		size float64
+	)
+	if err := checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
+	}
+	// Lets get adding
+	total := u.ByteSize() + unit.ByteSize()
+	switch {
+	case unit.Standard() != JEDEC:
+		// The exponent of another standard may not exist in this one, so the
+		// sum is measured by the greatest symbol not exceeding it
+		if abs := math.Abs(total); abs >= 1 {
+			nexp = int(math.Floor(math.Log2(abs) / 10))
+		}
+	case u.Exponent() >= unit.Exponent():
+		nexp = u.Exponent()
+	default:
+		nexp = unit.Exponent()
+	}
+	pair, ok := FindFloorUnitSymbolPair(JEDEC, nexp)
+	if !ok {
+		return nil, NewErrUnitExponentNotSupported(nexp)
+	}
+	lsym, gsym := pair.Least(), pair.Greatest()
+	smallSize := BytesToUnitSymbolSize(JEDEC, lsym, total)
+	lrgSize := BytesToUnitSymbolSize(JEDEC, gsym, total)
+	if math.Abs(lrgSize) < 1 {
+		nsym = lsym
+		size = smallSize
+	} else {
+		nsym = gsym
+		size = lrgSize
+	}
+	nu, err := NewJEDECUnit(size, nsym)
+	if err != nil {
+		return nil, err
+	}
+	return nu, nil
+}
+
+// Subtract attempts to subtract one Unit from another
+func (u *JEDECUnit) Subtract(unit Unit) Unit {
+	lok, rok := ValidateSymbols(u.Symbol(), unit.Symbol())
+	if lok && !rok {
+		return u
+	}
+	if rok && !lok {
+		return unit
+	}
+	nu, err := u.SubtractE(unit)
+	if err != nil {
+		nu, _ = NewJEDECUnit(0, Byte)
+	}
+	return nu
+}
+
+// SubtractE attempts to subtract one Unit from another, returning an error if
+// either symbol is invalid or the difference cannot be represented
+func (u *JEDECUnit) SubtractE(unit Unit) (Unit, error) {
+	var (
+		neg   bool
+		total float64
+		nexp  int
+		nu    *JEDECUnit
+		err   error
+	)
+	if err = checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
+	}
+	left := u.ByteSize()
+	right := unit.ByteSize()
+	if left >= right {
+		total = left - right
+	} else {
+		total = right - left
+		neg = true
+	}
+	if total > 0 {
+		nexp = int(math.Round(math.Log2(total) / 10))
+	}
+	// Differences of less than a byte are measured in bytes
+	if nexp < 0 {
+		nexp = 0
+	}
+	pair, ok := FindFloorUnitSymbolPair(JEDEC, nexp)
+	if !ok {
+		return nil, NewErrUnitExponentNotSupported(nexp)
+	}
+	lsym, gsym := pair.Least(), pair.Greatest()
+	smlSize := BytesToUnitSymbolSize(JEDEC, lsym, total)
+	lrgSize := BytesToUnitSymbolSize(JEDEC, gsym, total)
+	if lrgSize >= 0 {
+		if neg {
+			lrgSize = -lrgSize
+		}
+		nu, err = NewJEDECUnit(lrgSize, gsym)
+	} else {
+		if neg {
+			smlSize = -smlSize
+		}
+		nu, err = NewJEDECUnit(smlSize, lsym)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nu, nil
+}
+
+// Multiply attempts to multiply one Unit by another. As the product of two
+// Units has no meaningful size, a 0 Byte Unit is returned.
+//
+// Deprecated: use MultiplyE, which reports ErrUnitMultiplyNotSupported, or
+// Scale in order to multiply by a scalar
+func (u *JEDECUnit) Multiply(unit Unit) Unit {
+	nu, _ := NewJEDECUnit(0, Byte)
+	return nu
+}
+
+// Divide attempts to divide one Unit by another. As the quotient of two Units
+// is dimensionless, a 0 Byte Unit is returned.
+//
+// Deprecated: use DivideE, which reports ErrUnitDivideNotSupported, Ratio in
+// order to divide by a Unit, or Scale in order to divide by a scalar
+func (u *JEDECUnit) Divide(unit Unit) Unit {
+	nu, _ := NewJEDECUnit(0, Byte)
+	return nu
+}
+
+// Scale returns a new JEDECUnit with the same symbol and the size multiplied by n
+func (u *JEDECUnit) Scale(n float64) Unit {
+	return &JEDECUnit{u.size * n, u.symbol, u.exponent}
+}
+
+// MultiplyE returns an error, as the product of two Units has no meaningful
+// size; use Scale in order to multiply by a scalar
+func (u *JEDECUnit) MultiplyE(unit Unit) (Unit, error) {
+	if err := checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
+	}
+	return nil, NewErrUnitMultiplyNotSupported(u.Symbol(), unit.Symbol())
+}
+
+// DivideE returns an error, as the quotient of two Units is dimensionless; use
+// Ratio in order to divide by a Unit, or Scale in order to divide by a scalar
+func (u *JEDECUnit) DivideE(unit Unit) (Unit, error) {
+	if err := checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
+	}
+	return nil, NewErrUnitDivideNotSupported(u.Symbol(), unit.Symbol())
+}
+
+// Ratio returns the dimensionless ratio of the JEDECUnit to another Unit
+func (u *JEDECUnit) Ratio(unit Unit) (float64, error) {
+	return DivideUnits(u, unit)
+}
+
+// Over returns the Rate of the JEDECUnit transferred over a duration, i.e. the
+// JEDECUnit per d, or the zero Rate if d is not positive, as by NewRate
+func (u *JEDECUnit) Over(d time.Duration) Rate {
+	r, _ := NewRate(u, d)
+	return r
+}
+
+// TransferTime returns the time taken to transfer the JEDECUnit at a Rate, or the
+// maximum time.Duration if the Rate is not positive
+func (u *JEDECUnit) TransferTime(r Rate) time.Duration {
+	return transferTime(u, r)
+}
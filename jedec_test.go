@@ -0,0 +1,212 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleNewJEDECUnit() {
+	a, _ := NewJEDECUnit(16, GB)
+	_, err := NewJEDECUnit(1, GiB)
+	fmt.Println(a, a.ByteSize())
+	fmt.Println(err)
+	// Output:
+	// 16 GB 1.7179869184e+10
+	// unit symbol not supported: GiB
+}
+
+func ExampleParseWithStandard() {
+	a, _ := Parse("16 GB")
+	b, _ := ParseWithStandard("16 GB", JEDEC)
+	c, _ := ParseWithStandard("16 GiB", JEDEC)
+	fmt.Println(a.Standard(), a.ByteSize())
+	fmt.Println(b.Standard(), b.ByteSize())
+	fmt.Println(c.Standard(), c.ByteSize())
+	// Output:
+	// SI 1.6e+10
+	// JEDEC 1.7179869184e+10
+	// IEC 1.7179869184e+10
+}
+
+type testJEDECUnit struct {
+	unit     *JEDECUnit
+	expected float64
+}
+
+func TestJEDECUnit_ByteSize(t *testing.T) {
+	tt := []testJEDECUnit{
+		{&JEDECUnit{8, Bit, 0}, 1},
+		{&JEDECUnit{1, Byte, 0}, 1},
+		{&JEDECUnit{1, Kb, 1}, 128},
+		{&JEDECUnit{1, KB, 1}, 1024},
+		{&JEDECUnit{1, Mb, 2}, 131072},
+		{&JEDECUnit{1, MB, 2}, 1048576},
+		{&JEDECUnit{1.5, GB, 3}, 1610612736},
+		{&JEDECUnit{1, TB, 4}, 1099511627776},
+		{&JEDECUnit{1, "FooBar", 30}, 0},
+	}
+	for _, test := range tt {
+		assert.Equal(t, test.expected, test.unit.ByteSize(), test.unit.String())
+		assert.Equal(t, test.expected*8, test.unit.BitSize(), test.unit.String())
+	}
+}
+
+func TestNewJEDECUnit(t *testing.T) {
+	for sym, exp := range jedecUnitExponentMap {
+		u, err := NewJEDECUnit(1, sym)
+		assert.NoError(t, err, sym)
+		assert.Equal(t, exp, u.Exponent(), sym)
+		assert.Equal(t, JEDEC, u.Standard(), sym)
+	}
+	for _, sym := range []UnitSymbol{kB, KiB, PB, ""} {
+		_, err := NewJEDECUnit(1, sym)
+		assert.Error(t, err, sym)
+	}
+}
+
+func TestJEDECUnit_SizeInUnit(t *testing.T) {
+	u := &JEDECUnit{1, GB, 3}
+	assert.Equal(t, float64(1024), u.SizeInUnit(MB))
+	assert.Equal(t, float64(1048576), u.SizeInUnit(KB))
+	assert.Equal(t, float64(1), u.SizeInUnit(GiB))
+	assert.Equal(t, 1.073741824, u.SizeInUnit(kB)/1e6)
+	assert.Equal(t, float64(0), u.SizeInUnit("FooBar"))
+}
+
+func TestJEDECUnit_AddSubtract(t *testing.T) {
+	a := &JEDECUnit{1, GB, 3}
+	b := &JEDECUnit{512, MB, 2}
+	assert.Equal(t, &JEDECUnit{1.5, GB, 3}, a.Add(b))
+	assert.Equal(t, &JEDECUnit{0.5, GB, 3}, a.Subtract(b))
+	assert.Equal(t, &JEDECUnit{-0.5, GB, 3}, b.Subtract(a))
+	c, _ := NewIECUnit(1, GiB)
+	assert.Equal(t, &JEDECUnit{2, GB, 3}, a.Add(c))
+	_, err := a.AddE(&JEDECUnit{1, "FooBar", 0})
+	assert.Error(t, err)
+	assert.Equal(t, &JEDECUnit{0, Byte, 0}, a.Multiply(b))
+	assert.Equal(t, &JEDECUnit{0, Byte, 0}, a.Divide(b))
+}
+
+func TestJEDEC_Conversions(t *testing.T) {
+	u := &JEDECUnit{16, GB, 3}
+	iec, err := ConvertUnitStd(u, IEC)
+	assert.NoError(t, err)
+	assert.Equal(t, &IECUnit{16, GiB, 3}, iec)
+	si, err := ConvertUnitStd(u, SI)
+	assert.NoError(t, err)
+	assert.Equal(t, &SIUnit{17.179869184, GB, 9}, si)
+	back, err := ConvertUnitStd(&IECUnit{1536, KiB, 1}, JEDEC)
+	assert.NoError(t, err)
+	assert.Equal(t, &JEDECUnit{1.5, MB, 2}, back)
+	kb, err := ConvertTo(u, KB)
+	assert.NoError(t, err)
+	assert.Equal(t, &JEDECUnit{16 * 1024 * 1024, KB, 1}, kb)
+	assert.True(t, Equal(u, &IECUnit{16, GiB, 3}))
+	assert.Equal(t, Bytes(16*Gibibyte).String(), "16 GiB")
+	b, err := UnitToBytes(u)
+	assert.NoError(t, err)
+	assert.Equal(t, 16*Gibibyte, b)
+}
+
+func TestParseWithStandard(t *testing.T) {
+	tt := []parseExampleData{
+		{"1 KB", &JEDECUnit{1, KB, 1}, nil},
+		{"1 MB", &JEDECUnit{1, MB, 2}, nil},
+		{"1 Byte", &JEDECUnit{1, Byte, 0}, nil},
+		{"1 kB", &SIUnit{1, kB, 3}, nil},
+		{"1 PB", &SIUnit{1, PB, 15}, nil},
+		{"1 MiB", &IECUnit{1, MiB, 2}, nil},
+		{"1 XB", nil, ErrUnitStandardNotSupported},
+		{"one MB", nil, ErrUnitCouldNotBeParsed},
+	}
+	for _, d := range tt {
+		u, err := ParseWithStandard(d.input, JEDEC)
+		if d.err != nil {
+			assert.Error(t, err, d.input)
+			continue
+		}
+		assert.NoError(t, err, d.input)
+		assert.Equal(t, d.expected, u, d.input)
+	}
+}
+
+func TestJEDECUnit_Encoding(t *testing.T) {
+	var u JEDECUnit
+	assert.NoError(t, json.Unmarshal([]byte(`"16 GB"`), &u))
+	assert.Equal(t, JEDECUnit{16, GB, 3}, u)
+	assert.NoError(t, json.Unmarshal([]byte(`{"size":2,"symbol":"MB","standard":"JEDEC"}`), &u))
+	assert.Equal(t, JEDECUnit{2, MB, 2}, u)
+	assert.Error(t, json.Unmarshal([]byte(`{"size":2,"symbol":"MB","standard":"SI"}`), &u))
+	var j JSONUnit
+	assert.NoError(t, json.Unmarshal([]byte(`{"size":2,"symbol":"MB","standard":"JEDEC"}`), &j))
+	assert.Equal(t, &JEDECUnit{2, MB, 2}, j.Unit)
+	assert.NoError(t, u.UnmarshalText([]byte("4KB")))
+	assert.Equal(t, JEDECUnit{4, KB, 1}, u)
+	assert.NoError(t, u.Scan(int64(1536)))
+	assert.Equal(t, JEDECUnit{1.5, KB, 1}, u)
+	v, err := u.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5 KB", v)
+	q, err := FormatQuantity(&JEDECUnit{1.5, GB, 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "1536Mi", q)
+	std, err := ParseUnitStandard("JEDEC")
+	assert.NoError(t, err)
+	assert.Equal(t, JEDEC, std)
+	assert.Equal(t, "JEDEC", JEDEC.String())
+}
+
+func TestJEDECUnit_RoundTrip(t *testing.T) {
+	u := &JEDECUnit{16, GB, 3}
+	for _, format := range []JSONFormat{JSONString, JSONObject} {
+		b, err := json.Marshal(JSONUnit{u, format})
+		assert.NoError(t, err)
+		var j JSONUnit
+		assert.NoError(t, json.Unmarshal(b, &j), string(b))
+		assert.Equal(t, u, j.Unit, string(b))
+	}
+	b, err := Size{u}.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "16 GB (JEDEC)", string(b))
+	var s Size
+	assert.NoError(t, s.UnmarshalText(b))
+	assert.Equal(t, u, s.Unit)
+	b, err = u.MarshalText()
+	assert.NoError(t, err)
+	s = Size{}
+	assert.NoError(t, s.UnmarshalText(b))
+	assert.Equal(t, u, s.Unit)
+	var rt JEDECUnit
+	assert.NoError(t, rt.UnmarshalText(b))
+	assert.Equal(t, *u, rt)
+	assert.Error(t, rt.UnmarshalText([]byte("16 GB (SI)")))
+	for _, format := range []SQLFormat{SQLString, SQLInteger} {
+		v, err := SQLUnit{u, format}.Value()
+		assert.NoError(t, err)
+		sq := SQLUnit{Unit: &JEDECUnit{0, GB, 3}}
+		if format == SQLString {
+			sq.Unit = nil
+		}
+		assert.NoError(t, sq.Scan(v), "%v", v)
+		assert.Equal(t, u, sq.Unit, "%v", v)
+	}
+}
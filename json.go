@@ -0,0 +1,234 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// JSONFormat represents the form in which a Unit is marshaled to JSON
+type JSONFormat int
+
+// JSON format enums
+const (
+	// JSONString marshals a Unit as a string, i.e. "1.5 GiB", qualifying
+	// symbols of several standards when needed, i.e. "16 GB (JEDEC)"
+	JSONString JSONFormat = iota
+	// JSONObject marshals a Unit as an object, i.e.
+	// 	{"size":1.5,"symbol":"GiB","standard":"IEC"}
+	JSONObject
+)
+
+// unitJSON is the object form of a Unit
+type unitJSON struct {
+	Size     float64    `json:"size"`
+	Symbol   UnitSymbol `json:"symbol"`
+	Standard string     `json:"standard"`
+}
+
+// marshalUnitJSON marshals a Unit to JSON in the given format
+func marshalUnitJSON(u Unit, format JSONFormat) ([]byte, error) {
+	if format == JSONObject {
+		return json.Marshal(unitJSON{u.Size(), u.Symbol(), u.Standard().String()})
+	}
+	return json.Marshal(formatQualifiedUnit(u))
+}
+
+// unmarshalUnitJSON unmarshals either JSON form of a Unit, returning the
+// object form with an empty standard if none is given, and the format found
+func unmarshalUnitJSON(data []byte) (unitJSON, JSONFormat, error) {
+	var (
+		s   string
+		obj unitJSON
+		err error
+	)
+	if err = json.Unmarshal(data, &s); err == nil {
+		var (
+			std       UnitStandard
+			qualified bool
+		)
+		obj.Size, obj.Symbol, _, std, qualified, err = parseQualifiedSizeSymbol(s)
+		if qualified {
+			obj.Standard = std.String()
+		}
+		return obj, JSONString, err
+	}
+	if err = json.Unmarshal(data, &obj); err != nil {
+		return obj, JSONObject, NewErrUnitCouldNotBeParsed(string(data))
+	}
+	return obj, JSONObject, nil
+}
+
+// standard returns the UnitStandard of the object form of a Unit, or the
+// given fallback if none is set
+func (obj unitJSON) standard(fallback UnitStandard) (UnitStandard, error) {
+	if obj.Standard == "" {
+		return fallback, nil
+	}
+	return ParseUnitStandard(obj.Standard)
+}
+
+// isJSONNull reports whether data is the JSON null literal
+func isJSONNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+}
+
+// MarshalJSON implements json.Marshaler, marshaling an IECUnit as a string,
+// i.e. "1.5 GiB"
+func (u *IECUnit) MarshalJSON() ([]byte, error) {
+	return marshalUnitJSON(u, JSONString)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshaling an IECUnit from
+// either a string or an object, ignoring null
+func (u *IECUnit) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	obj, _, err := unmarshalUnitJSON(data)
+	if err != nil {
+		return err
+	}
+	std, err := obj.standard(IEC)
+	if err != nil {
+		return err
+	}
+	if std != IEC {
+		return NewErrUnitStandardNotSupported(std)
+	}
+	nu, err := NewIECUnit(obj.Size, obj.Symbol)
+	if err != nil {
+		return err
+	}
+	*u = *nu
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, marshaling a SIUnit as a string,
+// i.e. "1.5 GB"
+func (u *SIUnit) MarshalJSON() ([]byte, error) {
+	return marshalUnitJSON(u, JSONString)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshaling a SIUnit from
+// either a string or an object, ignoring null
+func (u *SIUnit) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	obj, _, err := unmarshalUnitJSON(data)
+	if err != nil {
+		return err
+	}
+	std, err := obj.standard(SI)
+	if err != nil {
+		return err
+	}
+	if std != SI {
+		return NewErrUnitStandardNotSupported(std)
+	}
+	nu, err := NewSIUnit(obj.Size, obj.Symbol)
+	if err != nil {
+		return err
+	}
+	*u = *nu
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, marshaling a JEDECUnit as a string,
+// i.e. "1.5 GB (JEDEC)"
+func (u *JEDECUnit) MarshalJSON() ([]byte, error) {
+	return marshalUnitJSON(u, JSONString)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, unmarshaling a JEDECUnit from
+// either a string or an object, ignoring null
+func (u *JEDECUnit) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	obj, _, err := unmarshalUnitJSON(data)
+	if err != nil {
+		return err
+	}
+	std, err := obj.standard(JEDEC)
+	if err != nil {
+		return err
+	}
+	if std != JEDEC {
+		return NewErrUnitStandardNotSupported(std)
+	}
+	nu, err := NewJEDECUnit(obj.Size, obj.Symbol)
+	if err != nil {
+		return err
+	}
+	*u = *nu
+	return nil
+}
+
+// JSONUnit wraps a Unit of any standard, marshaling it to JSON in the chosen
+// Format, and unmarshaling it from either JSON form. Strings are parsed as by
+// Parse, while objects may also declare the standard of the Unit
+type JSONUnit struct {
+	Unit
+	Format JSONFormat
+}
+
+// MarshalJSON implements json.Marshaler, marshaling a nil Unit as null
+func (j JSONUnit) MarshalJSON() ([]byte, error) {
+	if j.Unit == nil {
+		return []byte("null"), nil
+	}
+	return marshalUnitJSON(j.Unit, j.Format)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, recording the form found in
+// Format, and unmarshaling null as a nil Unit
+func (j *JSONUnit) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		j.Unit = nil
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		u, err := Parse(s)
+		if err != nil {
+			return err
+		}
+		j.Unit, j.Format = u, JSONString
+		return nil
+	}
+	obj, _, err := unmarshalUnitJSON(data)
+	if err != nil {
+		return err
+	}
+	fallback, _ := FindStandardBySymbol(obj.Symbol)
+	std, err := obj.standard(fallback)
+	if err != nil {
+		return err
+	}
+	if _, ok := FindUnitSymbolPairBySymbol(std, obj.Symbol); !ok {
+		return NewErrUnitSymbolNotSupported(obj.Symbol)
+	}
+	u, err := NewUnit(std, obj.Size, obj.Symbol)
+	if err != nil {
+		return err
+	}
+	j.Unit, j.Format = u, JSONObject
+	return nil
+}
@@ -0,0 +1,166 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleJSONUnit() {
+	var config struct {
+		Cache JSONUnit `json:"cache"`
+		Quota JSONUnit `json:"quota"`
+	}
+	in := `{"cache":"512 MiB","quota":{"size":1.5,"symbol":"TB","standard":"SI"}}`
+	_ = json.Unmarshal([]byte(in), &config)
+	fmt.Println(config.Cache, config.Cache.ByteSize())
+	fmt.Println(config.Quota, config.Quota.ByteSize())
+	out, _ := json.Marshal(config)
+	fmt.Println(string(out))
+	// Output:
+	// {512 MiB 0} 5.36870912e+08
+	// {1.5 TB 1} 1.5e+12
+	// {"cache":"512 MiB","quota":{"size":1.5,"symbol":"TB","standard":"SI"}}
+}
+
+func ExampleIECUnit_MarshalJSON() {
+	a, _ := NewIECUnit(1.5, GiB)
+	b, _ := json.Marshal(a)
+	fmt.Println(string(b))
+	// Output:
+	// "1.5 GiB"
+}
+
+type testUnmarshalJSON struct {
+	in       string
+	expected Unit
+	err      bool
+}
+
+func TestIECUnit_UnmarshalJSON(t *testing.T) {
+	tt := []testUnmarshalJSON{
+		{`"1.5 GiB"`, &IECUnit{1.5, GiB, 3}, false},
+		{`"1.5GiB"`, &IECUnit{1.5, GiB, 3}, false},
+		{`"8 Byte"`, &IECUnit{8, Byte, 0}, false},
+		{`{"size":2,"symbol":"Mib","standard":"IEC"}`, &IECUnit{2, Mib, 2}, false},
+		{`{"size":2,"symbol":"Mib"}`, &IECUnit{2, Mib, 2}, false},
+		{`null`, &IECUnit{}, false},
+		{`{"size":2,"symbol":"MB","standard":"SI"}`, nil, true},
+		{`{"size":2,"symbol":"MiB","standard":"FOO"}`, nil, true},
+		{`"1.5 GB"`, nil, true},
+		{`"one GiB"`, nil, true},
+		{`1.5`, nil, true},
+	}
+	for _, test := range tt {
+		var u IECUnit
+		err := json.Unmarshal([]byte(test.in), &u)
+		if test.err {
+			assert.Error(t, err, test.in)
+			continue
+		}
+		assert.NoError(t, err, test.in)
+		assert.Equal(t, test.expected, &u, test.in)
+	}
+}
+
+func TestSIUnit_JSON(t *testing.T) {
+	tt := []testUnmarshalJSON{
+		{`"1.5 GB"`, &SIUnit{1.5, GB, 9}, false},
+		{`{"size":2,"symbol":"kb","standard":"SI"}`, &SIUnit{2, kb, 3}, false},
+		{`{"size":2,"symbol":"MiB","standard":"IEC"}`, nil, true},
+		{`"1.5 GiB"`, nil, true},
+	}
+	for _, test := range tt {
+		var u SIUnit
+		err := json.Unmarshal([]byte(test.in), &u)
+		if test.err {
+			assert.Error(t, err, test.in)
+			continue
+		}
+		assert.NoError(t, err, test.in)
+		assert.Equal(t, test.expected, &u, test.in)
+		b, err := json.Marshal(&u)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("%q", u.String()), string(b))
+	}
+}
+
+func TestJSONUnit(t *testing.T) {
+	tt := []struct {
+		in, out  string
+		expected JSONUnit
+		err      bool
+	}{
+		{`"1.5 GiB"`, `"1.5 GiB"`, JSONUnit{&IECUnit{1.5, GiB, 3}, JSONString}, false},
+		{`"100MB"`, `"100 MB"`, JSONUnit{&SIUnit{100, MB, 6}, JSONString}, false},
+		{`"512 Byte (IEC)"`, `"512 Byte (IEC)"`, JSONUnit{&IECUnit{512, Byte, 0}, JSONString}, false},
+		{
+			`{"size":1,"symbol":"Byte","standard":"IEC"}`,
+			`{"size":1,"symbol":"Byte","standard":"IEC"}`,
+			JSONUnit{&IECUnit{1, Byte, 0}, JSONObject},
+			false,
+		},
+		{
+			`{"size":1,"symbol":"kB"}`,
+			`{"size":1,"symbol":"kB","standard":"SI"}`,
+			JSONUnit{&SIUnit{1, kB, 3}, JSONObject},
+			false,
+		},
+		{`null`, `null`, JSONUnit{}, false},
+		{`{"size":1,"symbol":"kB","standard":"IEC"}`, "", JSONUnit{}, true},
+		{`"1 FooBar"`, "", JSONUnit{}, true},
+		{`[]`, "", JSONUnit{}, true},
+	}
+	for _, test := range tt {
+		var j JSONUnit
+		err := json.Unmarshal([]byte(test.in), &j)
+		if test.err {
+			assert.Error(t, err, test.in)
+			continue
+		}
+		assert.NoError(t, err, test.in)
+		assert.Equal(t, test.expected, j, test.in)
+		b, err := json.Marshal(j)
+		assert.NoError(t, err)
+		assert.JSONEq(t, test.out, string(b))
+	}
+}
+
+func TestJSONUnit_UnknownSymbol(t *testing.T) {
+	var j JSONUnit
+	err := json.Unmarshal([]byte(`"1 FooBar"`), &j)
+	var pe *ParseError
+	if assert.True(t, errors.As(err, &pe), "string input returns a ParseError") {
+		assert.Equal(t, ComponentSymbol, pe.Component)
+	}
+	assert.True(t, errors.Is(err, ErrUnitSymbolNotSupported))
+	for _, in := range []string{
+		`{"size":1,"symbol":"FooBar"}`,
+		`{"size":1,"symbol":"FooBar","standard":"IEC"}`,
+		`{"size":1,"symbol":"kB","standard":"IEC"}`,
+	} {
+		err := json.Unmarshal([]byte(in), &j)
+		assert.True(t, errors.Is(err, ErrUnitSymbolNotSupported), in)
+		var se *SymbolError
+		assert.True(t, errors.As(err, &se), in)
+	}
+}
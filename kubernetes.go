@@ -0,0 +1,147 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+)
+
+// quantitySuffix maps a Kubernetes quantity suffix to a UnitSymbol
+type quantitySuffix struct {
+	suffix string
+	std    UnitStandard
+	symbol UnitSymbol
+}
+
+// quantitySuffixes lists the binary and decimal Kubernetes quantity suffixes,
+// from the greatest to the least within each standard
+var quantitySuffixes = []quantitySuffix{
+	{"Ei", IEC, EiB},
+	{"Pi", IEC, PiB},
+	{"Ti", IEC, TiB},
+	{"Gi", IEC, GiB},
+	{"Mi", IEC, MiB},
+	{"Ki", IEC, KiB},
+	{"E", SI, EB},
+	{"P", SI, PB},
+	{"T", SI, TB},
+	{"G", SI, GB},
+	{"M", SI, MB},
+	{"k", SI, kB},
+}
+
+// quantityRegexp matches "<number><suffix>", where the suffix is either a
+// binary or decimal suffix, or a decimal exponent. Sub-byte suffixes like "m"
+// are not matched, so that millibytes are rejected
+var quantityRegexp = regexp.MustCompile(`^([+-]?(?:\d+\.?\d*|\.\d+))([eE][+-]?\d+|Ki|Mi|Gi|Ti|Pi|Ei|k|M|G|T|P|E)?$`)
+
+// quantityNumberRegexp matches the number at the start of a quantity, which
+// locates the suffix of quantities not matched by quantityRegexp
+var quantityNumberRegexp = regexp.MustCompile(`^[+-]?(?:\d+\.?\d*|\.\d+)`)
+
+// ParseQuantity parses a size in the Kubernetes quantity format, as used for
+// memory and storage resources in manifests, i.e. "512Mi", "1.5Gi", "2G",
+// "1e9" or "100k". Binary suffixes (Ki, Mi, Gi, ...) return an IECUnit in the
+// matching byte symbol, while decimal suffixes (k, M, G, ...) return an
+// SIUnit. Numbers with a decimal exponent return an SIUnit measured by the
+// byte symbol of that exponent if there is one (1e9 is 1 GB), and in Bytes
+// otherwise. Plain numbers are measured in Bytes
+func ParseQuantity(s string) (Unit, error) {
+	m := quantityRegexp.FindStringSubmatch(s)
+	if len(m) < 3 {
+		if n := quantityNumberRegexp.FindStringIndex(s); n != nil {
+			return nil, &ParseError{s, n[1], ComponentSymbol, ErrUnitSymbolNotSupported}
+		}
+		return nil, &ParseError{s, 0, ComponentNumber, strconv.ErrSyntax}
+	}
+	size, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return nil, &ParseError{s, 0, ComponentNumber, err.(*strconv.NumError).Err}
+	}
+	suffix := m[2]
+	if suffix == "" {
+		return NewSIUnit(size, Byte)
+	}
+	for _, q := range quantitySuffixes {
+		if q.suffix == suffix {
+			return NewUnit(q.std, size, q.symbol)
+		}
+	}
+	exp, err := strconv.Atoi(suffix[1:])
+	if err != nil {
+		return nil, &ParseError{s, len(m[1]), ComponentSymbol, err.(*strconv.NumError).Err}
+	}
+	for _, q := range quantitySuffixes {
+		if q.std != SI {
+			continue
+		}
+		if e, _ := FindExponentBySymbol(q.symbol); e == exp {
+			return NewSIUnit(size, q.symbol)
+		}
+	}
+	size, err = strconv.ParseFloat(m[1]+"e"+strconv.Itoa(exp), 64)
+	if err != nil {
+		return nil, &ParseError{s, 0, ComponentNumber, err.(*strconv.NumError).Err}
+	}
+	return NewSIUnit(size, Byte)
+}
+
+// FormatQuantity formats a Unit in the canonical Kubernetes quantity format,
+// which is an integer with the greatest suffix of the standard of the Unit
+// that loses no precision, i.e. 1.5 GiB is formatted as "1536Mi" and 2.5 GB
+// as "2500M". Units of the IEC and JEDEC standards use binary suffixes and
+// other Units use decimal suffixes. Sizes which are not a whole number of
+// bytes are rounded up to the next byte, as Kubernetes does for fractional
+// quantities
+func FormatQuantity(u Unit) (string, error) {
+	r, ok := unitByteRat(u)
+	if !ok {
+		return "", NewErrUnitSymbolNotSupported(u.Symbol())
+	}
+	n := ratCeil(r)
+	if n.Sign() == 0 {
+		return "0", nil
+	}
+	std := SI
+	if u.Standard() == IEC || u.Standard() == JEDEC {
+		std = IEC
+	}
+	q, m := new(big.Int), new(big.Int)
+	for _, s := range quantitySuffixes {
+		if s.std != std {
+			continue
+		}
+		f, _ := unitSymbolByteRat(s.std, s.symbol)
+		q.QuoRem(n, f.Num(), m)
+		if m.Sign() == 0 {
+			return q.String() + s.suffix, nil
+		}
+	}
+	return n.String(), nil
+}
+
+// ratCeil returns the least integer greater than or equal to r
+func ratCeil(r *big.Rat) *big.Int {
+	// DivMod floors, as the denominator of a big.Rat is always positive
+	q, m := new(big.Int).DivMod(r.Num(), r.Denom(), new(big.Int))
+	if m.Sign() != 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return q
+}
@@ -0,0 +1,147 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleParseQuantity() {
+	for _, s := range []string{"512Mi", "1.5Gi", "2G", "1e9", "100k", "1024"} {
+		u, _ := ParseQuantity(s)
+		fmt.Println(u)
+	}
+	// Output:
+	// 512 MiB
+	// 1.5 GiB
+	// 2 GB
+	// 1 GB
+	// 100 kB
+	// 1024 Byte
+}
+
+func ExampleFormatQuantity() {
+	u, _ := NewIECUnit(1.5, GiB)
+	s, _ := FormatQuantity(u)
+	fmt.Println(s)
+	// Output:
+	// 1536Mi
+}
+
+type testQuantity struct {
+	in       string
+	expected Unit
+	err      bool
+}
+
+func TestParseQuantity(t *testing.T) {
+	tt := []testQuantity{
+		{"512Mi", &IECUnit{512, MiB, 2}, false},
+		{"1.5Gi", &IECUnit{1.5, GiB, 3}, false},
+		{"1Ki", &IECUnit{1, KiB, 1}, false},
+		{"2Ei", &IECUnit{2, EiB, 6}, false},
+		{"2G", &SIUnit{2, GB, 9}, false},
+		{"100k", &SIUnit{100, kB, 3}, false},
+		{"3E", &SIUnit{3, EB, 18}, false},
+		{"1e9", &SIUnit{1, GB, 9}, false},
+		{"1E6", &SIUnit{1, MB, 6}, false},
+		{"1.5e+3", &SIUnit{1.5, kB, 3}, false},
+		{"2e4", &SIUnit{20000, Byte, 0}, false},
+		{"5e-1", &SIUnit{0.5, Byte, 0}, false},
+		{"1024", &SIUnit{1024, Byte, 0}, false},
+		{"-1Gi", &IECUnit{-1, GiB, 3}, false},
+		{".5M", &SIUnit{0.5, MB, 6}, false},
+		{"500m", nil, true},
+		{"1K", nil, true},
+		{"1 Gi", nil, true},
+		{"1GiB", nil, true},
+		{"Gi", nil, true},
+		{"", nil, true},
+	}
+	for _, test := range tt {
+		u, err := ParseQuantity(test.in)
+		if test.err {
+			assert.Error(t, err, test.in)
+			continue
+		}
+		assert.NoError(t, err, test.in)
+		assert.Equal(t, test.expected, u, test.in)
+	}
+}
+
+type testFormatQuantity struct {
+	in       Unit
+	expected string
+}
+
+func TestFormatQuantity(t *testing.T) {
+	huge, _ := NewBigUnit(IEC, big.NewRat(1<<20, 1), EiB)
+	tt := []testFormatQuantity{
+		{&IECUnit{1.5, GiB, 3}, "1536Mi"},
+		{&IECUnit{512, MiB, 2}, "512Mi"},
+		{&IECUnit{1024, MiB, 2}, "1Gi"},
+		{&IECUnit{1000, Byte, 0}, "1000"},
+		{&IECUnit{1, Kib, 1}, "128"},
+		{&IECUnit{0, GiB, 3}, "0"},
+		{&IECUnit{-2, GiB, 3}, "-2Gi"},
+		{&SIUnit{2.5, GB, 9}, "2500M"},
+		{&SIUnit{1000, MB, 6}, "1G"},
+		{&SIUnit{1024, Byte, 0}, "1024"},
+		{&SIUnit{1, Bit, 0}, "1"},
+		{&SIUnit{1.5, Byte, 0}, "2"},
+		{huge, "1048576Ei"},
+	}
+	for _, test := range tt {
+		s, err := FormatQuantity(test.in)
+		assert.NoError(t, err, "%v", test.in)
+		assert.Equal(t, test.expected, s, "%v", test.in)
+		if test.in.Size() == 0 {
+			continue
+		}
+		u, err := ParseQuantity(s)
+		assert.NoError(t, err, s)
+		assert.True(t, Compare(u, test.in) >= 0, s)
+	}
+	_, err := FormatQuantity(&IECUnit{1, "XiB", 0})
+	assert.Error(t, err)
+}
+
+func TestQuantity_RoundTrip(t *testing.T) {
+	tt := []struct {
+		in, expected string
+	}{
+		{"1.1G", "1100M"},
+		{"1.3k", "1300"},
+		{"2.2M", "2200k"},
+		{"300.3M", "300300k"},
+		{"0.1Ki", "103"},
+		{"1.9Gi", "2040109466"},
+		{"1.5Gi", "1536Mi"},
+		{"100M", "100M"},
+	}
+	for _, test := range tt {
+		u, err := ParseQuantity(test.in)
+		assert.NoError(t, err, test.in)
+		s, err := FormatQuantity(u)
+		assert.NoError(t, err, test.in)
+		assert.Equal(t, test.expected, s, test.in)
+	}
+}
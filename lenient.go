@@ -0,0 +1,176 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import "strings"
+
+// AmbiguityPolicy decides how ParseLenient resolves a symbol which, once case
+// is ignored, matches both a bit and a byte symbol, i.e. "mb" or "GIB"
+type AmbiguityPolicy int
+
+// Ambiguity policy enums
+const (
+	// AmbiguityPreferBytes resolves ambiguous symbols as byte symbols, i.e.
+	// "mb" is parsed as MB. This is the default policy
+	AmbiguityPreferBytes AmbiguityPolicy = iota
+	// AmbiguityPreferBits resolves ambiguous symbols as bit symbols, i.e. "mb"
+	// is parsed as Mb
+	AmbiguityPreferBits
+	// AmbiguityError rejects ambiguous symbols with ErrUnitSymbolAmbiguous
+	AmbiguityError
+)
+
+// parseOptions holds the configuration of a call to ParseLenient
+type parseOptions struct {
+	policy    AmbiguityPolicy
+	standard  UnitStandard
+	preferStd bool
+}
+
+// ParseOption configures how ParseLenient resolves symbols
+type ParseOption func(*parseOptions)
+
+// WithAmbiguityPolicy sets the AmbiguityPolicy of ParseLenient, which is
+// AmbiguityPreferBytes by default
+func WithAmbiguityPolicy(p AmbiguityPolicy) ParseOption {
+	return func(o *parseOptions) {
+		o.policy = p
+	}
+}
+
+// WithPreferredStandard resolves symbols and names shared by several standards
+// in the given standard, i.e. "kilobyte" is parsed as a JEDEC KB rather than
+// an SI kB when JEDEC is given. By default the standard registered first wins,
+// as it does for Parse
+func WithPreferredStandard(std UnitStandard) ParseOption {
+	return func(o *parseOptions) {
+		o.standard, o.preferStd = std, true
+	}
+}
+
+// symbolCandidate is a UnitSymbol of a standard matched by a lenient parse
+type symbolCandidate struct {
+	std UnitStandard
+	sym UnitSymbol
+	bit bool
+}
+
+// ParseLenient parses a string representation of a unit size like Parse, but
+// also accepts symbols in any case ("10 gib"), singular and plural long names
+// ("10 gigabytes", "1 kibibyte") and abbreviations spelling out bits or bytes
+// ("10 GBytes", "100 Mbit"). Symbols which match exactly are parsed as by
+// Parse, while symbols matching both a bit and a byte symbol once case is
+// ignored (like "mb") are resolved by the AmbiguityPolicy
+func ParseLenient(s string, opts ...ParseOption) (Unit, error) {
+	o := &parseOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	trimmed := strings.TrimSpace(s)
+	// Offsets are reported within s rather than the trimmed string
+	lead := strings.Index(s, trimmed)
+	size, word, off, err := parseSizeSymbol(trimmed)
+	if err != nil {
+		pe := err.(*ParseError)
+		return nil, &ParseError{s, lead + pe.Offset, pe.Component, pe.Err}
+	}
+	std, sym, err := resolveLenientSymbol(string(word), o)
+	if err != nil {
+		return nil, &ParseError{s, lead + off, ComponentSymbol, err}
+	}
+	return NewUnit(std, size, sym)
+}
+
+// resolveLenientSymbol resolves a word into the UnitSymbol of a standard
+func resolveLenientSymbol(word string, o *parseOptions) (UnitStandard, UnitSymbol, error) {
+	// Exact symbols keep their strict meaning
+	if o.preferStd {
+		if std, ok := findStandardForSymbol(o.standard, UnitSymbol(word)); ok {
+			return std, UnitSymbol(word), nil
+		}
+	} else if std, ok := FindStandardBySymbol(UnitSymbol(word)); ok {
+		return std, UnitSymbol(word), nil
+	}
+	var candidates []symbolCandidate
+	for _, p := range DefaultRegistry.Pairs() {
+		if c := (symbolCandidate{p.Standard(), p.Least(), true}); matchesLenient(word, c) {
+			candidates = append(candidates, c)
+		}
+		if c := (symbolCandidate{p.Standard(), p.Greatest(), false}); matchesLenient(word, c) {
+			candidates = append(candidates, c)
+		}
+	}
+	if len(candidates) == 0 {
+		return 0, "", ErrUnitSymbolNotSupported
+	}
+	var bits, bytes []symbolCandidate
+	for _, c := range candidates {
+		if c.bit {
+			bits = append(bits, c)
+		} else {
+			bytes = append(bytes, c)
+		}
+	}
+	switch {
+	case len(bits) == 0:
+		candidates = bytes
+	case len(bytes) == 0:
+		candidates = bits
+	case o.policy == AmbiguityPreferBits:
+		candidates = bits
+	case o.policy == AmbiguityError:
+		return 0, "", ErrUnitSymbolAmbiguous
+	default:
+		candidates = bytes
+	}
+	if o.preferStd {
+		for _, c := range candidates {
+			if c.std == o.standard {
+				return c.std, c.sym, nil
+			}
+		}
+	}
+	return candidates[0].std, candidates[0].sym, nil
+}
+
+// matchesLenient reports whether a word names a candidate symbol, ignoring
+// case, by its symbol, its singular or plural long name, or its prefix
+// followed by "bit(s)" or "byte(s)"
+func matchesLenient(word string, c symbolCandidate) bool {
+	sym := string(c.sym)
+	if strings.EqualFold(word, sym) {
+		return true
+	}
+	if name, ok := unitSymbolNames[c.sym]; ok {
+		if strings.EqualFold(word, name) || strings.EqualFold(word, name+"s") {
+			return true
+		}
+	}
+	kind, suffix := "byte", "B"
+	if c.bit {
+		kind, suffix = "bit", "b"
+	}
+	switch {
+	case c.sym == Bit || c.sym == Byte:
+		// "b" and "B" keep their case, as the bare prefixes of Bit and Byte
+		return word == suffix
+	case strings.HasSuffix(sym, suffix):
+		prefix := strings.TrimSuffix(sym, suffix)
+		return strings.EqualFold(word, prefix+kind) || strings.EqualFold(word, prefix+kind+"s")
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleParseLenient() {
+	for _, s := range []string{"10 gib", "10 gigabytes", "1 kibibyte", "2 GBytes", "100 Mbit", "5 mb"} {
+		u, _ := ParseLenient(s)
+		fmt.Println(u)
+	}
+	u, _ := ParseLenient("5 mb", WithAmbiguityPolicy(AmbiguityPreferBits))
+	fmt.Println(u)
+	_, err := ParseLenient("5 mb", WithAmbiguityPolicy(AmbiguityError))
+	fmt.Println(err)
+	// Output:
+	// 10 GiB
+	// 10 GB
+	// 1 KiB
+	// 2 GB
+	// 100 Mb
+	// 5 MB
+	// 5 Mb
+	// parsing "5 mb": invalid symbol at offset 2: unit symbol ambiguous
+}
+
+type testParseLenient struct {
+	input    string
+	opts     []ParseOption
+	expected Unit
+	err      error
+}
+
+func TestParseLenient(t *testing.T) {
+	bits := WithAmbiguityPolicy(AmbiguityPreferBits)
+	strict := WithAmbiguityPolicy(AmbiguityError)
+	jedec := WithPreferredStandard(JEDEC)
+	tt := []testParseLenient{
+		// Exact symbols keep their strict meaning
+		{"1 MiB", nil, &IECUnit{1, MiB, 2}, nil},
+		{"1 Mib", nil, &IECUnit{1, Mib, 2}, nil},
+		{"1 kb", nil, &SIUnit{1, kb, 3}, nil},
+		{"1 KB", nil, &JEDECUnit{1, KB, 1}, nil},
+		{"1 MB", []ParseOption{jedec}, &JEDECUnit{1, MB, 2}, nil},
+		// Case variants
+		{"10 gib", nil, &IECUnit{10, GiB, 3}, nil},
+		{"10 GIB", []ParseOption{bits}, &IECUnit{10, Gib, 3}, nil},
+		{"10 mb", nil, &SIUnit{10, MB, 6}, nil},
+		{"10 mb", []ParseOption{jedec}, &JEDECUnit{10, MB, 2}, nil},
+		{"10 Kb", nil, &JEDECUnit{10, Kb, 1}, nil},
+		{"10 tB", nil, &SIUnit{10, TB, 12}, nil},
+		{"10 BYTE", nil, &SIUnit{10, Byte, 0}, nil},
+		// Long names
+		{"10 gigabytes", nil, &SIUnit{10, GB, 9}, nil},
+		{"1 gigabyte", nil, &SIUnit{1, GB, 9}, nil},
+		{"1 kibibyte", nil, &IECUnit{1, KiB, 1}, nil},
+		{"3 Mebibits", nil, &IECUnit{3, Mib, 2}, nil},
+		{"2 kilobytes", []ParseOption{jedec}, &JEDECUnit{2, KB, 1}, nil},
+		{"8 bits", nil, &SIUnit{8, Bit, 0}, nil},
+		{"8 bytes", nil, &SIUnit{8, Byte, 0}, nil},
+		{"1 quettabyte", nil, &SIUnit{1, QB, 30}, nil},
+		// Abbreviations
+		{"2 GBytes", nil, &SIUnit{2, GB, 9}, nil},
+		{"2 GiBytes", nil, &IECUnit{2, GiB, 3}, nil},
+		{"100 Mbit", nil, &SIUnit{100, Mb, 6}, nil},
+		{"100 kbits", nil, &SIUnit{100, kb, 3}, nil},
+		{"100 Kibit", nil, &IECUnit{100, Kib, 1}, nil},
+		{"64 B", nil, &SIUnit{64, Byte, 0}, nil},
+		{"64 b", nil, &SIUnit{64, Bit, 0}, nil},
+		{" 12GB ", nil, &SIUnit{12, GB, 9}, nil},
+		// Ambiguities
+		{"10 mb", []ParseOption{bits}, &SIUnit{10, Mb, 6}, nil},
+		{"10 mb", []ParseOption{strict}, nil, ErrUnitSymbolAmbiguous},
+		{"10 gigabytes", []ParseOption{strict}, &SIUnit{10, GB, 9}, nil},
+		// Errors
+		{"10 gigs", nil, nil, ErrUnitSymbolNotSupported},
+		{"ten gigabytes", nil, nil, strconv.ErrSyntax},
+	}
+	for _, test := range tt {
+		u, err := ParseLenient(test.input, test.opts...)
+		if test.err != nil {
+			assert.True(t, errors.Is(err, test.err), "%s: %v", test.input, err)
+			assert.True(t, errors.Is(err, ErrUnitCouldNotBeParsed), test.input)
+			continue
+		}
+		assert.NoError(t, err, test.input)
+		assert.Equal(t, test.expected, u, test.input)
+	}
+}
+
+func TestParse_Strict(t *testing.T) {
+	for _, s := range []string{"10 gib", "10 gigabytes", "1 kibibyte", "2 GBytes", "100 Mbit"} {
+		_, err := Parse(s)
+		assert.Error(t, err, s)
+	}
+}
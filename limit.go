@@ -0,0 +1,194 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+)
+
+// LimitExceededError is returned by a reader limited by LimitReader once more
+// than its limit is available to read. It matches ErrUnitAboveMaximum with
+// errors.Is
+type LimitExceededError struct {
+	Limit Unit
+}
+
+// Error returns the message of a LimitExceededError, i.e.
+// unit size above maximum: limit of 8 MiB exceeded
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%v: limit of %v exceeded", ErrUnitAboveMaximum, e.Limit)
+}
+
+// Unwrap returns ErrUnitAboveMaximum
+func (e *LimitExceededError) Unwrap() error {
+	return ErrUnitAboveMaximum
+}
+
+// limitBytes returns the number of bytes of a limit, rounded to the nearest
+// byte. Limits which overflow int64 bytes are unlimited, while limits with
+// unsupported symbols allow nothing
+func limitBytes(max Unit) int64 {
+	b, err := UnitToBytes(max)
+	switch {
+	case err == nil && b > 0:
+		return int64(b)
+	case err != nil && max.ByteSize() > 0:
+		return math.MaxInt64
+	}
+	return 0
+}
+
+// limitedReader reads up to a limit from an underlying io.Reader
+type limitedReader struct {
+	r     io.Reader
+	limit Unit
+	n     int64
+	err   error
+}
+
+// LimitReader returns an io.Reader reading up to max from r, i.e. "8 MiB".
+// Unlike io.LimitReader, which stops at the limit with io.EOF, reading more
+// than max returns a *LimitExceededError, so that oversized inputs are told
+// apart from inputs ending at the limit
+func LimitReader(r io.Reader, max Unit) io.Reader {
+	return &limitedReader{r: r, limit: max, n: limitBytes(max)}
+}
+
+// Read implements io.Reader, reading one byte past the limit in order to
+// detect inputs exceeding it
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if int64(len(p))-1 > l.n {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	if int64(n) <= l.n {
+		l.n -= int64(n)
+		l.err = err
+		return n, err
+	}
+	n = int(l.n)
+	l.n = 0
+	l.err = &LimitExceededError{l.limit}
+	return n, l.err
+}
+
+// limitedBody is the body of a request limited by MaxBodyHandler
+type limitedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// limitedResponseWriter answers a request whose body exceeded the limit of
+// MaxBodyHandler with 413 Request Entity Too Large, unless the handler already
+// wrote the response header or answered with an error status of its own
+type limitedResponseWriter struct {
+	http.ResponseWriter
+	body        *limitedReader
+	wroteHeader bool
+	rejected    bool
+}
+
+// exceeded reports whether more than the limit was read from the body
+func (w *limitedResponseWriter) exceeded() bool {
+	_, ok := w.body.err.(*LimitExceededError)
+	return ok
+}
+
+// reject answers the request with 413 Request Entity Too Large, discarding
+// the response of the handler
+func (w *limitedResponseWriter) reject() {
+	w.wroteHeader, w.rejected = true, true
+	w.Header().Del("Content-Length")
+	http.Error(w.ResponseWriter, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+}
+
+// WriteHeader implements http.ResponseWriter, replacing a successful status
+// with 413 Request Entity Too Large once the body exceeded the limit
+func (w *limitedResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	if code < http.StatusBadRequest && w.exceeded() {
+		w.reject()
+		return
+	}
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write implements http.ResponseWriter, discarding the response of the
+// handler once the request was rejected
+func (w *limitedResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.rejected {
+		return len(p), nil
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher when the underlying http.ResponseWriter does
+func (w *limitedResponseWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok && !w.rejected {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying http.ResponseWriter
+func (w *limitedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// MaxBodyHandler returns an http.Handler which limits the bodies of requests
+// to h to max, i.e. "8 MiB". Requests declaring a larger Content-Length are
+// answered with 413 Request Entity Too Large without calling h. Otherwise,
+// reading more than max from the body returns a *LimitExceededError, and the
+// request is answered with 413 Request Entity Too Large unless h wrote the
+// response header beforehand or answered with an error status of its own
+func MaxBodyHandler(h http.Handler, max Unit) http.Handler {
+	n := limitBytes(max)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > n {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if r.Body == nil || r.Body == http.NoBody {
+			h.ServeHTTP(w, r)
+			return
+		}
+		body := &limitedReader{r: r.Body, limit: max, n: n}
+		r.Body = limitedBody{body, r.Body}
+		lw := &limitedResponseWriter{ResponseWriter: w, body: body}
+		h.ServeHTTP(lw, r)
+		if !lw.wroteHeader && lw.exceeded() {
+			lw.reject()
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleLimitReader() {
+	max, _ := Parse("1 KiB")
+	_, err := ioutil.ReadAll(LimitReader(strings.NewReader(strings.Repeat("x", 2048)), max))
+	var le *LimitExceededError
+	fmt.Println(errors.As(err, &le), le.Limit)
+	fmt.Println(err)
+	// Output:
+	// true 1 KiB
+	// unit size above maximum: limit of 1 KiB exceeded
+}
+
+type testLimitReader struct {
+	size     int
+	max      Unit
+	read     int
+	exceeded bool
+}
+
+func TestLimitReader(t *testing.T) {
+	tt := []testLimitReader{
+		{1024, &IECUnit{1, KiB, 1}, 1024, false},
+		{1025, &IECUnit{1, KiB, 1}, 1024, true},
+		{1000, &SIUnit{1, kB, 3}, 1000, false},
+		{1024, &SIUnit{1, kB, 3}, 1000, true},
+		{0, &SIUnit{0, Byte, 0}, 0, false},
+		{1, &SIUnit{0, Byte, 0}, 0, true},
+		{16, &SIUnit{1, UnitSymbol("FooBar"), 30}, 0, true},
+		{16, &SIUnit{1, Bit, 0}, 0, true},
+		{16, &IECUnit{1, QiB, 10}, 16, false},
+	}
+	for _, test := range tt {
+		b, err := ioutil.ReadAll(LimitReader(strings.NewReader(strings.Repeat("x", test.size)), test.max))
+		assert.Len(t, b, test.read, "%d bytes limited to %v", test.size, test.max)
+		if test.exceeded {
+			var le *LimitExceededError
+			assert.True(t, errors.As(err, &le), "%d bytes limited to %v", test.size, test.max)
+			assert.True(t, errors.Is(err, ErrUnitAboveMaximum))
+			assert.Equal(t, test.max, le.Limit)
+			continue
+		}
+		assert.NoError(t, err, "%d bytes limited to %v", test.size, test.max)
+	}
+}
+
+func TestLimitReader_Sticky(t *testing.T) {
+	max, _ := Parse("4 Byte")
+	r := LimitReader(strings.NewReader("0123456789"), max)
+	buf := make([]byte, 3)
+	n, err := r.Read(buf)
+	assert.Equal(t, 3, n)
+	assert.NoError(t, err)
+	n, err = r.Read(buf)
+	assert.Equal(t, 1, n)
+	assert.Error(t, err)
+	n, err2 := r.Read(buf)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, err, err2)
+	n, err = r.Read(nil)
+	assert.Equal(t, 0, n)
+	assert.Error(t, err)
+}
+
+func TestMaxBodyHandler(t *testing.T) {
+	max, _ := Parse("1 KiB")
+	h := MaxBodyHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		var le *LimitExceededError
+		if errors.As(err, &le) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		fmt.Fprintf(w, "%d", len(b))
+	}), max)
+
+	// Within the limit
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/", strings.NewReader(strings.Repeat("x", 1024))))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1024", rec.Body.String())
+
+	// Content-Length above the limit is rejected without calling the handler
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/", strings.NewReader(strings.Repeat("x", 1025))))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Equal(t, "Request Entity Too Large\n", rec.Body.String())
+
+	// Bodies of unknown length are limited while read
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/", io.MultiReader(strings.NewReader(strings.Repeat("x", 2048))))
+	req.ContentLength = -1
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Equal(t, "unit size above maximum: limit of 1 KiB exceeded\n", rec.Body.String())
+
+	// Requests without a body
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "0", rec.Body.String())
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestMaxBodyHandler_Close(t *testing.T) {
+	max, _ := Parse("1 KiB")
+	body := &closeRecorder{Reader: strings.NewReader("x")}
+	h := MaxBodyHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.Body.Close())
+	}), max)
+	req := httptest.NewRequest("POST", "/", nil)
+	req.Body = body
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	assert.True(t, body.closed)
+}
+
+func TestMaxBodyHandler_Chunked(t *testing.T) {
+	max, _ := Parse("8 Byte")
+	srv := httptest.NewServer(MaxBodyHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"chunked"}, r.TransferEncoding)
+		// The error is ignored by the handler
+		b, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+		_, _ = w.Write(b)
+	}), max))
+	defer srv.Close()
+	for _, test := range []struct {
+		body     string
+		expected int
+	}{
+		{strings.Repeat("x", 100), http.StatusRequestEntityTooLarge},
+		{strings.Repeat("x", 8), http.StatusOK},
+	} {
+		// A body of unknown length is sent chunked
+		res, err := http.Post(srv.URL, "text/plain", ioutil.NopCloser(strings.NewReader(test.body)))
+		assert.NoError(t, err)
+		b, err := ioutil.ReadAll(res.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, res.Body.Close())
+		assert.Equal(t, test.expected, res.StatusCode, string(b))
+	}
+}
+
+func TestMaxBodyHandler_HeaderWritten(t *testing.T) {
+	max, _ := Parse("8 Byte")
+	h := MaxBodyHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = ioutil.ReadAll(r.Body)
+	}), max)
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/", io.MultiReader(strings.NewReader(strings.Repeat("x", 100))))
+	req.ContentLength = -1
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+}
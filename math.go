@@ -1,9 +1,5 @@
 package bitty
 
-import (
-	"fmt"
-)
-
 // AddUnits takes two units with valid symbols, sums them, then returns a new unit
 // AddUnits will always default to the left unit's symbol and exponent
 func AddUnits(lu, ru Unit) (Unit, error) {
@@ -16,13 +12,13 @@ func AddUnits(lu, ru Unit) (Unit, error) {
 	// validate that the units can be added to each other
 	lok, rok = ValidateSymbols(lu.Symbol(), ru.Symbol())
 	if !lok && !rok {
-		return nil, fmt.Errorf("unable to add units with invalid symbols together: %s + %s", lu.Symbol(), ru.Symbol())
+		return nil, newArithmeticError("+", lu, ru, ErrUnitSymbolNotSupported)
 	}
 	if lok && !rok {
-		return lu, fmt.Errorf("unable to add unit with invalid symbol: %s", ru.Symbol())
+		return lu, newArithmeticError("+", lu, ru, ErrUnitSymbolNotSupported)
 	}
 	if rok && !lok {
-		return ru, fmt.Errorf("unable to add unit with invalid symbol: %s", lu.Symbol())
+		return ru, newArithmeticError("+", lu, ru, ErrUnitSymbolNotSupported)
 	}
 
 	leftByte, rightByte := lu.ByteSize(), ru.ByteSize()
@@ -46,6 +42,14 @@ func AddUnits(lu, ru Unit) (Unit, error) {
 			symbol:   newSymbol,
 			exponent: newExponent,
 		}
+	case JEDEC:
+		u = &JEDECUnit{
+			size:     newSize,
+			symbol:   newSymbol,
+			exponent: newExponent,
+		}
+	default:
+		return NewUnit(lu.Standard(), newSize, newSymbol)
 	}
 	return u, nil
 }
@@ -62,13 +66,13 @@ func SubtractUnits(lu, ru Unit) (Unit, error) {
 	// validate that the units can be added to each other
 	lok, rok = ValidateSymbols(lu.Symbol(), ru.Symbol())
 	if !lok && !rok {
-		return nil, fmt.Errorf("unable to add units with invalid symbols together: %s + %s", lu.Symbol(), ru.Symbol())
+		return nil, newArithmeticError("-", lu, ru, ErrUnitSymbolNotSupported)
 	}
 	if lok && !rok {
-		return lu, fmt.Errorf("unable to subtract unit with invalid symbol: %s", ru.Symbol())
+		return lu, newArithmeticError("-", lu, ru, ErrUnitSymbolNotSupported)
 	}
 	if rok && !lok {
-		return ru, fmt.Errorf("unable to subtract unit with invalid symbol: %s", lu.Symbol())
+		return ru, newArithmeticError("-", lu, ru, ErrUnitSymbolNotSupported)
 	}
 
 	leftByte, rightByte := lu.ByteSize(), ru.ByteSize()
@@ -92,6 +96,74 @@ func SubtractUnits(lu, ru Unit) (Unit, error) {
 			symbol:   newSymbol,
 			exponent: newExponent,
 		}
+	case JEDEC:
+		u = &JEDECUnit{
+			size:     newSize,
+			symbol:   newSymbol,
+			exponent: newExponent,
+		}
+	default:
+		return NewUnit(lu.Standard(), newSize, newSymbol)
 	}
 	return u, nil
 }
+
+// MultiplyUnits takes two units with valid symbols and returns an error, as
+// the product of two units has no meaningful size. Use MultiplyUnit or
+// Unit.Scale in order to multiply a unit by a scalar
+func MultiplyUnits(lu, ru Unit) (Unit, error) {
+	lok, rok := ValidateSymbols(lu.Symbol(), ru.Symbol())
+	if !lok && !rok {
+		return nil, newArithmeticError("*", lu, ru, ErrUnitSymbolNotSupported)
+	}
+	if lok && !rok {
+		return lu, newArithmeticError("*", lu, ru, ErrUnitSymbolNotSupported)
+	}
+	if rok && !lok {
+		return ru, newArithmeticError("*", lu, ru, ErrUnitSymbolNotSupported)
+	}
+	return nil, NewErrUnitMultiplyNotSupported(lu.Symbol(), ru.Symbol())
+}
+
+// MultiplyUnit takes a unit with a valid symbol and a scalar, then returns a
+// new unit with the size multiplied by the scalar
+// MultiplyUnit will always default to the unit's symbol and exponent
+func MultiplyUnit(u Unit, n float64) (Unit, error) {
+	if !ValidateSymbol(u.Symbol()) {
+		return nil, newArithmeticError("*", u, n, ErrUnitSymbolNotSupported)
+	}
+	return u.Scale(n), nil
+}
+
+// DivideUnit takes a unit with a valid symbol and a non-zero scalar, then
+// returns a new unit with the size divided by the scalar
+// DivideUnit will always default to the unit's symbol and exponent
+func DivideUnit(u Unit, n float64) (Unit, error) {
+	if !ValidateSymbol(u.Symbol()) {
+		return nil, newArithmeticError("/", u, n, ErrUnitSymbolNotSupported)
+	}
+	if n == 0 {
+		return nil, newArithmeticError("/", u, n, ErrUnitDivideByZero)
+	}
+	return u.Scale(1 / n), nil
+}
+
+// DivideUnits takes two units with valid symbols, divides the left by the
+// right, then returns the dimensionless ratio between them
+func DivideUnits(lu, ru Unit) (float64, error) {
+	lok, rok := ValidateSymbols(lu.Symbol(), ru.Symbol())
+	if !lok && !rok {
+		return 0, newArithmeticError("/", lu, ru, ErrUnitSymbolNotSupported)
+	}
+	if lok && !rok {
+		return 0, newArithmeticError("/", lu, ru, ErrUnitSymbolNotSupported)
+	}
+	if rok && !lok {
+		return 0, newArithmeticError("/", lu, ru, ErrUnitSymbolNotSupported)
+	}
+	rightByte := ru.ByteSize()
+	if rightByte == 0 {
+		return 0, newArithmeticError("/", lu, ru, ErrUnitDivideByZero)
+	}
+	return lu.ByteSize() / rightByte, nil
+}
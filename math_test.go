@@ -1,6 +1,7 @@
 package bitty
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -220,3 +221,65 @@ func TestSubtractUnitsSadPath(t *testing.T) {
 	}
 
 }
+
+func TestMultiplyUnits(t *testing.T) {
+	left, _ := Parse("4 GiB")
+	right, _ := Parse("2 GB")
+	_, err := MultiplyUnits(left, right)
+	assert.EqualError(t, err, "unit multiplication by unit not supported: GiB * GB")
+	_, err = MultiplyUnits(left, &IECUnit{size: 1, symbol: UnitSymbol("giib")})
+	assert.Error(t, err)
+}
+
+func TestMultiplyUnit(t *testing.T) {
+	tt := []struct {
+		in       string
+		n        float64
+		expected string
+	}{
+		{"4 GiB", 3, "12 GiB"},
+		{"4 GiB", 0.25, "1 GiB"},
+		{"100 MB", -2, "-200 MB"},
+	}
+	for _, test := range tt {
+		u, err := Parse(test.in)
+		assert.NoError(t, err)
+		actualUnit, err := MultiplyUnit(u, test.n)
+		assert.NoError(t, err)
+		actual := fmt.Sprintf("%.f %s", actualUnit.Size(), actualUnit.Symbol())
+		assert.Equal(t, test.expected, actual)
+	}
+	_, err := MultiplyUnit(&IECUnit{size: 1, symbol: UnitSymbol("giib")}, 2)
+	assert.Error(t, err)
+}
+
+func TestDivideUnit(t *testing.T) {
+	u, _ := Parse("12 GiB")
+	actualUnit, err := DivideUnit(u, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, "3 GiB", fmt.Sprintf("%.f %s", actualUnit.Size(), actualUnit.Symbol()))
+	_, err = DivideUnit(u, 0)
+	assert.True(t, errors.Is(err, ErrUnitDivideByZero))
+}
+
+func TestDivideUnits(t *testing.T) {
+	tt := []struct {
+		l, r     string
+		expected float64
+	}{
+		{"1 GiB", "512 MiB", 2},
+		{"1 GB", "1 kB", 1000000},
+		{"1 KiB", "1 kB", 1.024},
+	}
+	for _, test := range tt {
+		left, _ := Parse(test.l)
+		right, _ := Parse(test.r)
+		actual, err := DivideUnits(left, right)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, actual)
+	}
+	left, _ := Parse("1 GiB")
+	right, _ := Parse("0 MB")
+	_, err := DivideUnits(left, right)
+	assert.True(t, errors.Is(err, ErrUnitDivideByZero))
+}
@@ -0,0 +1,101 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import "math/big"
+
+// normalizeKind selects between the bit and byte symbols when normalizing
+type normalizeKind int
+
+const (
+	normalizeKeep normalizeKind = iota
+	normalizeBits
+	normalizeBytes
+)
+
+// normalizeOptions holds the configuration of a call to Normalize
+type normalizeOptions struct {
+	threshold *big.Rat
+	kind      normalizeKind
+}
+
+// NormalizeOption configures how Normalize picks a UnitSymbol
+type NormalizeOption func(*normalizeOptions)
+
+// WithThreshold sets the least size a Unit may have once normalized, which is
+// 1 by default. i.e. with a threshold of 0.5, 512 MiB normalizes to 0.5 GiB.
+// Thresholds which are not positive or finite are ignored
+func WithThreshold(t float64) NormalizeOption {
+	return func(o *normalizeOptions) {
+		if r := new(big.Rat).SetFloat64(t); r != nil && r.Sign() > 0 {
+			o.threshold = r
+		}
+	}
+}
+
+// PreferBits normalizes Units into bit symbols, i.e. 1 kB into 8 kb
+func PreferBits() NormalizeOption {
+	return func(o *normalizeOptions) {
+		o.kind = normalizeBits
+	}
+}
+
+// PreferBytes normalizes Units into byte symbols, i.e. 8 kb into 1 kB
+func PreferBytes() NormalizeOption {
+	return func(o *normalizeOptions) {
+		o.kind = normalizeBytes
+	}
+}
+
+// Normalize returns a Unit of the same standard measured by the symbol with the
+// greatest exponent which keeps the size at or above a threshold (1 by default),
+// i.e. 1536 MiB normalizes to 1.5 GiB and 0.5 MiB normalizes to 512 KiB. Units
+// keep their kind (bit or byte) unless PreferBits or PreferBytes is given. If
+// the Unit symbol is not supported, the Unit itself is returned
+func Normalize(u Unit, opts ...NormalizeOption) Unit {
+	o := &normalizeOptions{threshold: big.NewRat(1, 1)}
+	for _, opt := range opts {
+		opt(o)
+	}
+	std := u.Standard()
+	r, ok := unitByteRat(u)
+	if !ok {
+		return u
+	}
+	bits := isBitUnitSymbol(std, u.Symbol())
+	switch o.kind {
+	case normalizeBits:
+		bits = true
+	case normalizeBytes:
+		bits = false
+	}
+	sym, factor, ok := bestUnitSymbol(std, r, bits, o.threshold)
+	if !ok {
+		return u
+	}
+	if _, ok := u.(*BigUnit); ok {
+		if nu, err := newBigUnitFromBytes(std, sym, r); err == nil {
+			return nu
+		}
+		return u
+	}
+	size, _ := r.Quo(r, factor).Float64()
+	if nu, err := NewUnit(std, size, sym); err == nil {
+		return nu
+	}
+	return u
+}
@@ -0,0 +1,113 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleNormalize() {
+	a, _ := Parse("1536 MiB")
+	b, _ := Parse("0.5 MiB")
+	c, _ := Parse("512 MiB")
+	fmt.Println(Normalize(a))
+	fmt.Println(Normalize(b))
+	fmt.Println(Normalize(c, WithThreshold(0.5)))
+	fmt.Println(Normalize(a, PreferBits()))
+	// Output:
+	// 1.5 GiB
+	// 512 KiB
+	// 0.5 GiB
+	// 12 Gib
+}
+
+type testNormalize struct {
+	in       Unit
+	opts     []NormalizeOption
+	expected Unit
+}
+
+func TestNormalize(t *testing.T) {
+	bu := &IECUnit{1, UnitSymbol("FooBar"), 30}
+	tt := []testNormalize{
+		{&IECUnit{1024, KiB, 1}, nil, &IECUnit{1, MiB, 2}},
+		{&IECUnit{1023, KiB, 1}, nil, &IECUnit{1023, KiB, 1}},
+		{&IECUnit{0.6, TiB, 4}, nil, &IECUnit{614.4, GiB, 3}},
+		{&IECUnit{-2048, MiB, 2}, nil, &IECUnit{-2, GiB, 3}},
+		{&IECUnit{0, YiB, 8}, nil, &IECUnit{0, Byte, 0}},
+		{&IECUnit{0.5, Byte, 0}, nil, &IECUnit{0.5, Byte, 0}},
+		{&IECUnit{2, YiB, 8}, []NormalizeOption{WithThreshold(1024)}, &IECUnit{2048, ZiB, 7}},
+		{&IECUnit{8192, Kib, 1}, nil, &IECUnit{8, Mib, 2}},
+		{&IECUnit{8192, Kib, 1}, []NormalizeOption{PreferBytes()}, &IECUnit{1, MiB, 2}},
+		{&IECUnit{1, KiB, 1}, []NormalizeOption{PreferBits()}, &IECUnit{8, Kib, 1}},
+		{&IECUnit{4, Bit, 0}, []NormalizeOption{PreferBytes()}, &IECUnit{0.5, Byte, 0}},
+		{&SIUnit{1500, kB, 3}, nil, &SIUnit{1.5, MB, 6}},
+		{&SIUnit{0.5, GB, 9}, nil, &SIUnit{500, MB, 6}},
+		{&SIUnit{0.5, GB, 9}, []NormalizeOption{WithThreshold(0.5)}, &SIUnit{0.5, GB, 9}},
+		{&SIUnit{0.5, GB, 9}, []NormalizeOption{WithThreshold(0), WithThreshold(-1)}, &SIUnit{500, MB, 6}},
+		{bu, nil, bu},
+	}
+	for _, test := range tt {
+		actual := Normalize(test.in, test.opts...)
+		assert.Equal(t, test.expected.Standard(), actual.Standard())
+		assert.Equal(t, test.expected.Symbol(), actual.Symbol(), "%v", test.in)
+		assert.InDelta(t, test.expected.Size(), actual.Size(), 1e-9, "%v", test.in)
+	}
+}
+
+func TestNormalize_BigUnit(t *testing.T) {
+	a, _ := NewBigUnit(IEC, big.NewRat(1, 1), YiB)
+	b := a.Add(&IECUnit{1, Byte, 0})
+	c, ok := Normalize(a.Scale(1.0 / 1024)).(*BigUnit)
+	assert.True(t, ok)
+	assert.Equal(t, ZiB, c.Symbol())
+	assert.Equal(t, big.NewRat(1, 1), c.Rat())
+	d := Normalize(b).(*BigUnit)
+	assert.Equal(t, YiB, d.Symbol())
+	assert.Equal(t, 0, Compare(b, d))
+}
+
+type testConvertUnitStd struct {
+	in       Unit
+	std      UnitStandard
+	expected Unit
+}
+
+func TestConvertUnitStd(t *testing.T) {
+	tt := []testConvertUnitStd{
+		{&SIUnit{1, GB, 9}, IEC, &IECUnit{953.67431640625, MiB, 2}},
+		{&SIUnit{15, kB, 3}, SI, &SIUnit{15, kB, 3}},
+		{&IECUnit{1, GiB, 3}, SI, &SIUnit{1.073741824, GB, 9}},
+		{&IECUnit{1, Gib, 3}, SI, &SIUnit{1.073741824, Gb, 9}},
+		{&IECUnit{0.6, TiB, 4}, IEC, &IECUnit{614.4, GiB, 3}},
+	}
+	for _, test := range tt {
+		actual, err := ConvertUnitStd(test.in, test.std)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected.Standard(), actual.Standard())
+		assert.Equal(t, test.expected.Symbol(), actual.Symbol(), "%v", test.in)
+		assert.InDelta(t, test.expected.Size(), actual.Size(), 1e-9, "%v", test.in)
+	}
+	_, err := ConvertUnitStd(&SIUnit{1, GB, 9}, UnitStandard(50))
+	assert.Error(t, err)
+	_, err = ConvertUnitStd(&SIUnit{1, UnitSymbol("FooBar"), 30}, IEC)
+	assert.EqualError(t, err, "unit symbol not supported: FooBar")
+}
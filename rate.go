@@ -0,0 +1,182 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Rate is a data rate: a Unit transferred per time interval, i.e. 100 Mb per
+// second (100 Mbps) or 1.5 GiB per second
+type Rate struct {
+	unit     Unit
+	interval time.Duration
+}
+
+// NewRate returns a Rate of a Unit transferred per a positive time interval,
+// or ErrUnitRateNotPositive if the interval is zero or negative
+func NewRate(u Unit, interval time.Duration) (Rate, error) {
+	if interval <= 0 {
+		return Rate{}, fmt.Errorf("%w: %v", ErrUnitRateNotPositive, Rate{u, interval})
+	}
+	return Rate{u, interval}, nil
+}
+
+// Unit returns the Unit transferred per interval of a Rate
+func (r Rate) Unit() Unit {
+	return r.unit
+}
+
+// Interval returns the time interval of a Rate
+func (r Rate) Interval() time.Duration {
+	return r.interval
+}
+
+// BitsPerSecond returns the Rate measured in bits per second
+func (r Rate) BitsPerSecond() float64 {
+	return r.BytesPerSecond() * 8
+}
+
+// BytesPerSecond returns the Rate measured in bytes per second
+func (r Rate) BytesPerSecond() float64 {
+	if r.unit == nil || r.interval <= 0 {
+		return 0
+	}
+	return r.unit.ByteSize() / r.interval.Seconds()
+}
+
+// PerSecond returns the Rate measured per second, keeping its symbol, i.e.
+// 1 GiB per minute is 17.066666666666666 MiB/s
+func (r Rate) PerSecond() Rate {
+	return Rate{r.For(time.Second), time.Second}
+}
+
+// For returns the Unit transferred at the Rate for a duration, measured by the
+// symbol of the Rate, i.e. 100 Mbps for 1 minute is 6000 Mb
+func (r Rate) For(d time.Duration) Unit {
+	if r.unit == nil || r.interval <= 0 {
+		return nil
+	}
+	return r.unit.Scale(float64(d) / float64(r.interval))
+}
+
+// ConvertTo converts a Rate to an arbitrary UnitSymbol of any standard, keeping
+// its interval, i.e. 100 Mbps is 12.5 MB/s or 11.920928955078125 MiB/s
+func (r Rate) ConvertTo(sym UnitSymbol) (Rate, error) {
+	if r.unit == nil {
+		return Rate{}, NewErrUnitSymbolNotSupported("")
+	}
+	u, err := ConvertTo(r.unit, sym)
+	if err != nil {
+		return Rate{}, err
+	}
+	return Rate{u, r.interval}, nil
+}
+
+// String returns a Rate in the format of "<unit>/<interval>", i.e. "100 Mb/s",
+// "1.5 GiB/min" or "1 MB/100ms"
+func (r Rate) String() string {
+	if r.unit == nil {
+		return ""
+	}
+	for _, d := range rateIntervals {
+		if d.interval == r.interval {
+			return fmt.Sprintf("%v/%s", r.unit, d.names[0])
+		}
+	}
+	return fmt.Sprintf("%v/%v", r.unit, r.interval)
+}
+
+// rateIntervals holds the names of the intervals of a Rate, of which the first
+// is used by Rate.String
+var rateIntervals = []struct {
+	interval time.Duration
+	names    []string
+}{
+	{time.Second, []string{"s", "sec", "second"}},
+	{time.Minute, []string{"min", "minute"}},
+	{time.Hour, []string{"h", "hr", "hour"}},
+	{time.Millisecond, []string{"ms"}},
+}
+
+// ParseRate parses a string representation of a Rate in the format of
+// "<unit>/<interval>" or "<unit>ps" (per second), where the unit is parsed as
+// by ParseLenient, i.e. "100 Mbps", "1.5 GiB/s", "10Gb/s", "100 Mbit/s" or
+// "500 MB/min". Intervals
+// are named ("s", "sec", "min", "h", ...) or given as a time.Duration, i.e.
+// "1 MiB/100ms". Errors are returned as *ParseError
+func ParseRate(s string) (Rate, error) {
+	var unit, interval string
+	off := strings.LastIndex(s, "/")
+	switch {
+	case off >= 0:
+		unit, interval = s[:off], s[off+1:]
+		off++
+	case strings.HasSuffix(s, "ps"):
+		off = len(s) - len("ps")
+		unit, interval = s[:off], "s"
+	default:
+		return Rate{}, &ParseError{s, len(s), ComponentDuration, ErrUnitCouldNotBeParsed}
+	}
+	// Offsets within unit are offsets within s, as unit starts s
+	u, err := ParseLenient(unit)
+	if err != nil {
+		if pe, ok := err.(*ParseError); ok {
+			return Rate{}, &ParseError{s, pe.Offset, pe.Component, pe.Err}
+		}
+		return Rate{}, err
+	}
+	d, ok := parseRateInterval(strings.TrimSpace(interval))
+	if !ok {
+		return Rate{}, &ParseError{s, off, ComponentDuration, ErrUnitCouldNotBeParsed}
+	}
+	return Rate{u, d}, nil
+}
+
+// parseRateInterval parses the name of an interval, or a positive
+// time.Duration
+func parseRateInterval(s string) (time.Duration, bool) {
+	for _, d := range rateIntervals {
+		for _, name := range d.names {
+			if s == name {
+				return d.interval, true
+			}
+		}
+	}
+	d, err := time.ParseDuration(s)
+	return d, err == nil && d > 0
+}
+
+// transferTime returns the time taken to transfer a Unit at a Rate, or the
+// maximum time.Duration if the Rate is not positive or the time overflows
+func transferTime(u Unit, r Rate) time.Duration {
+	bps := r.BytesPerSecond()
+	if bps <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	ns := math.Round(u.ByteSize() / bps * float64(time.Second))
+	switch {
+	case ns >= math.MaxInt64:
+		return time.Duration(math.MaxInt64)
+	case ns <= math.MinInt64:
+		return time.Duration(math.MinInt64)
+	}
+	return time.Duration(ns)
+}
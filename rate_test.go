@@ -0,0 +1,218 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleParseRate() {
+	r, _ := ParseRate("100 Mbps")
+	b, _ := r.ConvertTo(MB)
+	u, _ := Parse("1 GB")
+	fmt.Println(r)
+	fmt.Println(b)
+	fmt.Println(r.For(time.Minute))
+	fmt.Println(u.TransferTime(r))
+	// Output:
+	// 100 Mb/s
+	// 12.5 MB/s
+	// 6000 Mb
+	// 1m20s
+}
+
+func ExampleIECUnit_Over() {
+	u, _ := NewIECUnit(1, GiB)
+	r := u.Over(8 * time.Second)
+	fmt.Println(r)
+	fmt.Println(r.PerSecond())
+	// Output:
+	// 1 GiB/8s
+	// 0.125 GiB/s
+}
+
+type testParseRate struct {
+	input    string
+	unit     Unit
+	interval time.Duration
+}
+
+func TestParseRate(t *testing.T) {
+	tt := []testParseRate{
+		{"100 Mbps", &SIUnit{100, Mb, 6}, time.Second},
+		{"100Mbps", &SIUnit{100, Mb, 6}, time.Second},
+		{"80 MBps", &SIUnit{80, MB, 6}, time.Second},
+		{"1.5 GiB/s", &IECUnit{1.5, GiB, 3}, time.Second},
+		{"10Gb/s", &SIUnit{10, Gb, 9}, time.Second},
+		{"10 Gb / sec", &SIUnit{10, Gb, 9}, time.Second},
+		{"500 MB/min", &SIUnit{500, MB, 6}, time.Minute},
+		{"2 TB/h", &SIUnit{2, TB, 12}, time.Hour},
+		{"1 MiB/100ms", &IECUnit{1, MiB, 2}, 100 * time.Millisecond},
+		{"8 Bit/s", &SIUnit{8, Bit, 0}, time.Second},
+		{"100 Mbit/s", &SIUnit{100, Mb, 6}, time.Second},
+		{"100 megabits/s", &SIUnit{100, Mb, 6}, time.Second},
+		{"20 MBytes/s", &SIUnit{20, MB, 6}, time.Second},
+		{" 1 GiB/s", &IECUnit{1, GiB, 3}, time.Second},
+	}
+	for _, test := range tt {
+		r, err := ParseRate(test.input)
+		assert.NoError(t, err, test.input)
+		assert.Equal(t, test.unit, r.Unit(), test.input)
+		assert.Equal(t, test.interval, r.Interval(), test.input)
+	}
+}
+
+type testParseRateError struct {
+	input     string
+	offset    int
+	component ParseComponent
+	err       error
+}
+
+func TestParseRate_Errors(t *testing.T) {
+	tt := []testParseRateError{
+		{"100 Mb", 6, ComponentDuration, ErrUnitCouldNotBeParsed},
+		{"100 Mb/fortnight", 7, ComponentDuration, ErrUnitCouldNotBeParsed},
+		{"100 Mb/0s", 7, ComponentDuration, ErrUnitCouldNotBeParsed},
+		{"100 Mb/-1s", 7, ComponentDuration, ErrUnitCouldNotBeParsed},
+		{"100 Xbps", 4, ComponentSymbol, ErrUnitSymbolNotSupported},
+		{"fast/s", 0, ComponentNumber, ErrUnitCouldNotBeParsed},
+		{" 5 x/s", 3, ComponentSymbol, ErrUnitSymbolNotSupported},
+		{"  fast/s", 2, ComponentNumber, ErrUnitCouldNotBeParsed},
+	}
+	for _, test := range tt {
+		_, err := ParseRate(test.input)
+		var pe *ParseError
+		if assert.True(t, errors.As(err, &pe), "%s: %v", test.input, err) {
+			assert.Equal(t, test.input, pe.Input)
+			assert.Equal(t, test.offset, pe.Offset, test.input)
+			assert.Equal(t, test.component, pe.Component, test.input)
+			assert.True(t, errors.Is(err, test.err), test.input)
+		}
+	}
+}
+
+func TestNewRate(t *testing.T) {
+	u, _ := NewIECUnit(1, GiB)
+	r, err := NewRate(u, 8*time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, u.Over(8*time.Second), r)
+	_, err = NewRate(u, 0)
+	assert.True(t, errors.Is(err, ErrUnitRateNotPositive))
+	_, err = NewRate(u, -time.Second)
+	assert.True(t, errors.Is(err, ErrUnitRateNotPositive))
+	assert.EqualError(t, err, "unit rate not positive: 1 GiB/-1s")
+}
+
+type testRateConvertTo struct {
+	input    string
+	to       UnitSymbol
+	expected string
+}
+
+func TestRate_ConvertTo(t *testing.T) {
+	tt := []testRateConvertTo{
+		{"100 Mbps", MB, "12.5 MB/s"},
+		{"100 Mbps", MiB, "11.920928955078125 MiB/s"},
+		{"1 GiB/s", Gib, "8 Gib/s"},
+		{"1 GiB/s", Gb, "8.589934592 Gb/s"},
+		{"12.5 MB/min", Mb, "100 Mb/min"},
+		{"1 KB/s", kb, "8.192 kb/s"},
+	}
+	for _, test := range tt {
+		r, err := ParseRate(test.input)
+		assert.NoError(t, err, test.input)
+		c, err := r.ConvertTo(test.to)
+		assert.NoError(t, err, test.input)
+		assert.Equal(t, r.Interval(), c.Interval(), test.input)
+		assert.Equal(t, test.expected, c.String(), test.input)
+	}
+	r, _ := ParseRate("1 GiB/s")
+	_, err := r.ConvertTo(UnitSymbol("FooBar"))
+	assert.True(t, errors.Is(err, ErrUnitSymbolNotSupported))
+	_, err = Rate{}.ConvertTo(MB)
+	assert.Error(t, err)
+}
+
+func TestRate(t *testing.T) {
+	r, _ := ParseRate("1 GiB/min")
+	assert.Equal(t, float64(1<<30)/60, r.BytesPerSecond())
+	assert.Equal(t, float64(1<<30)/60*8, r.BitsPerSecond())
+	assert.Equal(t, &IECUnit{1.0 / 60, GiB, 3}, r.PerSecond().Unit())
+	assert.Equal(t, time.Second, r.PerSecond().Interval())
+	assert.Equal(t, &IECUnit{0.5, GiB, 3}, r.For(30*time.Second))
+	assert.Equal(t, "1 GiB/min", r.String())
+	assert.Equal(t, "1 GiB/1.5s", (&IECUnit{1, GiB, 3}).Over(1500*time.Millisecond).String())
+
+	var zero Rate
+	assert.Equal(t, float64(0), zero.BytesPerSecond())
+	assert.Nil(t, zero.For(time.Second))
+	assert.Equal(t, "", zero.String())
+}
+
+type testTransferTime struct {
+	unit     Unit
+	rate     string
+	expected time.Duration
+}
+
+func TestUnit_TransferTime(t *testing.T) {
+	bu, _ := NewBigUnit(SI, big.NewRat(1, 1), GB)
+	tt := []testTransferTime{
+		{&SIUnit{1, GB, 9}, "100 Mbps", 80 * time.Second},
+		{&IECUnit{1, GiB, 3}, "128 MiB/s", 8 * time.Second},
+		{&JEDECUnit{1, GB, 3}, "1 GiB/min", time.Minute},
+		{&JEDECUnit{1, GB, 3}, "1 GB/min", 64424509440 * time.Nanosecond},
+		{&SIUnit{1, GB, 9}, "1 GB/min", time.Minute},
+		{bu, "1 GB/h", time.Hour},
+		{&SIUnit{1, kB, 3}, "1 MB/s", time.Millisecond},
+		{&SIUnit{0, Byte, 0}, "1 MB/s", 0},
+		{&SIUnit{1, QB, 30}, "1 Bit/s", time.Duration(math.MaxInt64)},
+	}
+	for _, test := range tt {
+		r, err := ParseRate(test.rate)
+		assert.NoError(t, err, test.rate)
+		assert.Equal(t, test.expected, test.unit.TransferTime(r), "%v at %s", test.unit, test.rate)
+	}
+	u, _ := NewSIUnit(1, GB)
+	assert.Equal(t, time.Duration(math.MaxInt64), u.TransferTime(Rate{}))
+	assert.Equal(t, time.Duration(math.MaxInt64), u.TransferTime(u.Over(0)))
+}
+
+func TestUnit_Over(t *testing.T) {
+	bu, _ := NewBigUnit(SI, big.NewRat(1, 1), GB)
+	for _, u := range []Unit{&IECUnit{1, GiB, 3}, &SIUnit{1, GB, 9}, &JEDECUnit{1, GB, 3}, bu} {
+		r := u.Over(8 * time.Second)
+		assert.Equal(t, u, r.Unit())
+		assert.Equal(t, 8*time.Second, r.Interval())
+		assert.Equal(t, u.ByteSize()/8, r.BytesPerSecond())
+		assert.Equal(t, 8*time.Second, u.TransferTime(r))
+		for _, d := range []time.Duration{0, -time.Second} {
+			r = u.Over(d)
+			assert.Equal(t, Rate{}, r, "%v over %v", u, d)
+			assert.Equal(t, "", r.String())
+			assert.Equal(t, time.Duration(math.MaxInt64), u.TransferTime(r))
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimitOption configures a RateLimitedReader or RateLimitedWriter
+type RateLimitOption func(*tokenBucket)
+
+// WithBurst sets the greatest Unit which may be transferred at once after the
+// transfer has been idle, which is the Unit transferred in one second at the
+// limited Rate by default
+func WithBurst(u Unit) RateLimitOption {
+	return func(b *tokenBucket) {
+		if n, err := UnitToBytes(u); err == nil && n > 0 {
+			b.burst = float64(n)
+		}
+	}
+}
+
+// WithLimitClock sets the Clock measuring and waiting out the limited Rate of
+// a transfer, which is the system clock by default
+func WithLimitClock(c Clock) RateLimitOption {
+	return func(b *tokenBucket) {
+		b.clock = c
+	}
+}
+
+// tokenBucket throttles a transfer to a rate in bytes per second. Tokens are
+// bytes, refilled at the rate up to the burst
+type tokenBucket struct {
+	clock Clock
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full tokenBucket for a Rate configured by opts
+func newTokenBucket(r Rate, opts []RateLimitOption) (*tokenBucket, error) {
+	rate := r.BytesPerSecond()
+	if !(rate > 0) || math.IsInf(rate, 1) {
+		return nil, fmt.Errorf("%w: %v", ErrUnitRateNotPositive, r)
+	}
+	// The default burst is one second of transfer, and at least a byte
+	b := &tokenBucket{clock: systemClock{}, rate: rate, burst: math.Max(1, math.Floor(rate))}
+	for _, opt := range opts {
+		opt(b)
+	}
+	b.tokens = b.burst
+	b.last = b.clock.Now()
+	return b, nil
+}
+
+// size returns the greatest number of bytes to transfer at once, which is the
+// burst
+func (b *tokenBucket) size(n int) int {
+	if float64(n) > b.burst {
+		return int(b.burst)
+	}
+	return n
+}
+
+// wait takes n tokens from the bucket, sleeping until the bucket has refilled
+// if it runs short
+func (b *tokenBucket) wait(n int) {
+	if n <= 0 {
+		return
+	}
+	b.mu.Lock()
+	now := b.clock.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens -= float64(n)
+	var d time.Duration
+	if b.tokens < 0 {
+		d = time.Duration(math.Ceil(-b.tokens / b.rate * float64(time.Second)))
+	}
+	b.mu.Unlock()
+	if d > 0 {
+		b.clock.Sleep(d)
+	}
+}
+
+// RateLimitedReader is an io.Reader throttling reads from an underlying
+// io.Reader to a Rate
+type RateLimitedReader struct {
+	r io.Reader
+	b *tokenBucket
+}
+
+// RateLimitReader returns a RateLimitedReader reading from r at no more than
+// rate on average, i.e. "50 MiB/s", after an initial burst. Reads are no
+// larger than the burst. An error is returned if the rate is not positive
+func RateLimitReader(r io.Reader, rate Rate, opts ...RateLimitOption) (*RateLimitedReader, error) {
+	b, err := newTokenBucket(rate, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &RateLimitedReader{r, b}, nil
+}
+
+// Read implements io.Reader, waiting after a read until the Rate allows it
+func (l *RateLimitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p[:l.b.size(len(p))])
+	l.b.wait(n)
+	return n, err
+}
+
+// RateLimitedWriter is an io.Writer throttling writes to an underlying
+// io.Writer to a Rate
+type RateLimitedWriter struct {
+	w io.Writer
+	b *tokenBucket
+}
+
+// RateLimitWriter returns a RateLimitedWriter writing to w at no more than
+// rate on average, i.e. "200 Mbps", after an initial burst. Writes larger than
+// the burst are split. An error is returned if the rate is not positive
+func RateLimitWriter(w io.Writer, rate Rate, opts ...RateLimitOption) (*RateLimitedWriter, error) {
+	b, err := newTokenBucket(rate, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &RateLimitedWriter{w, b}, nil
+}
+
+// Write implements io.Writer, waiting before each write until the Rate allows
+// it
+func (l *RateLimitedWriter) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		chunk := p[:l.b.size(len(p))]
+		l.b.wait(len(chunk))
+		n, err := l.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		if n < len(chunk) {
+			return written, io.ErrShortWrite
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
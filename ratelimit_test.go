@@ -0,0 +1,114 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitReader(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+	rate, _ := ParseRate("1 MiB/s")
+	r, err := RateLimitReader(bytes.NewReader(make([]byte, 5<<20)), rate, WithLimitClock(clock))
+	assert.NoError(t, err)
+	n, err := io.Copy(ioutil.Discard, r)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5<<20), n)
+	// The first MiB is the burst, while the rest is read at 1 MiB/s
+	assert.InDelta(t, 4*time.Second, clock.Now().Sub(start), float64(time.Millisecond))
+}
+
+func TestRateLimitReader_Burst(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+	rate, _ := ParseRate("100 KiB/s")
+	burst, _ := Parse("10 KiB")
+	r, err := RateLimitReader(bytes.NewReader(make([]byte, 1<<20)), rate, WithLimitClock(clock), WithBurst(burst))
+	assert.NoError(t, err)
+	buf := make([]byte, 64<<10)
+	n, err := r.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 10<<10, n)
+	assert.Equal(t, time.Duration(0), clock.Now().Sub(start))
+	n, err = r.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 10<<10, n)
+	assert.Equal(t, 100*time.Millisecond, clock.Now().Sub(start))
+
+	// Idle time refills the bucket up to the burst only
+	clock.Advance(time.Minute)
+	start = clock.Now()
+	for i := 0; i < 3; i++ {
+		_, _ = r.Read(buf)
+	}
+	assert.Equal(t, 200*time.Millisecond, clock.Now().Sub(start))
+}
+
+func TestRateLimitWriter(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+	rate, _ := ParseRate("200 Mbps")
+	burst, _ := Parse("1 MB")
+	var buf bytes.Buffer
+	w, err := RateLimitWriter(&buf, rate, WithLimitClock(clock), WithBurst(burst))
+	assert.NoError(t, err)
+	n, err := w.Write(make([]byte, 50000000))
+	assert.NoError(t, err)
+	assert.Equal(t, 50000000, n)
+	assert.Equal(t, 50000000, buf.Len())
+	// 49 MB after the burst at 25 MB/s
+	assert.Equal(t, 1960*time.Millisecond, clock.Now().Sub(start))
+}
+
+func TestRateLimitWriter_ShortWrite(t *testing.T) {
+	rate, _ := ParseRate("1 MB/s")
+	w, err := RateLimitWriter(shortWriter{}, rate, WithLimitClock(newFakeClock()))
+	assert.NoError(t, err)
+	n, err := w.Write(make([]byte, 10))
+	assert.Equal(t, 5, n)
+	assert.Equal(t, io.ErrShortWrite, err)
+}
+
+func TestRateLimit_Errors(t *testing.T) {
+	u, _ := NewSIUnit(0, MB)
+	for _, rate := range []Rate{{}, u.Over(time.Second), (&SIUnit{-1, MB, 6}).Over(time.Second)} {
+		_, err := RateLimitReader(bytes.NewReader(nil), rate)
+		assert.True(t, errors.Is(err, ErrUnitRateNotPositive), "%v", rate)
+		_, err = RateLimitWriter(ioutil.Discard, rate)
+		assert.True(t, errors.Is(err, ErrUnitRateNotPositive), "%v", rate)
+	}
+}
+
+func TestRateLimit_SlowRate(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+	rate, _ := ParseRate("8 Bit/s")
+	r, err := RateLimitReader(bytes.NewReader(make([]byte, 3)), rate, WithLimitClock(clock))
+	assert.NoError(t, err)
+	b, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Len(t, b, 3)
+	assert.Equal(t, 2*time.Second, clock.Now().Sub(start))
+}
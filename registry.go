@@ -0,0 +1,300 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"strconv"
+	"sync"
+)
+
+// standardRadix describes how a UnitStandard measures its symbols: the
+// greatest symbol of the pair of exponent e measures radix^(step*e) bytes,
+// while the least symbol measures an eighth of it
+type standardRadix struct {
+	name  string
+	radix int
+	step  int
+}
+
+// Registry holds the UnitStandards and UnitSymbolPairs known to bitty. All of
+// its methods are safe for concurrent use
+type Registry struct {
+	mu        sync.RWMutex
+	standards map[UnitStandard]standardRadix
+	pairs     []UnitSymbolPair
+}
+
+// DefaultRegistry is the Registry through which the package level functions
+// (Parse, NewUnit, the Find functions, ...) resolve UnitStandards and
+// UnitSymbolPairs. It holds the SI, IEC and JEDEC standards
+var DefaultRegistry = NewDefaultRegistry()
+
+// NewDefaultRegistry returns a new Registry holding the built in standards and
+// UnitSymbolPairs, as held by DefaultRegistry before any registration
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.standards[SI] = standardRadix{"SI", 10, 1}
+	r.standards[IEC] = standardRadix{"IEC", 2, 10}
+	r.standards[JEDEC] = standardRadix{"JEDEC", 2, 10}
+	r.pairs = append(r.pairs, unitSymbolPairs...)
+	return r
+}
+
+// RegisterStandard registers a new UnitStandard in the DefaultRegistry, as
+// described by Registry.RegisterStandard
+func RegisterStandard(name string, radix, step int) (UnitStandard, error) {
+	return DefaultRegistry.RegisterStandard(name, radix, step)
+}
+
+// Register registers a UnitSymbolPair in the DefaultRegistry, as described by
+// Registry.Register
+func Register(pair UnitSymbolPair) error {
+	return DefaultRegistry.Register(pair)
+}
+
+// NewRegistry returns an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{standards: make(map[UnitStandard]standardRadix)}
+}
+
+// RegisterStandard registers a new UnitStandard by name, in which the greatest
+// symbol of the pair of exponent e measures radix^(step*e) bytes, i.e. IEC has
+// a radix of 2 and a step of 10, while SI has a radix of 10 and a step of 1.
+// The Bit and Byte pair is registered for the new standard, while its other
+// pairs are registered with Register. Units of custom standards are created
+// as *BigUnit values by NewUnit
+func (r *Registry) RegisterStandard(name string, radix, step int) (UnitStandard, error) {
+	if name == "" || radix < 2 || step < 1 {
+		return 0, &RegistrationError{name, ErrUnitStandardInvalid}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	next := UnitStandard(0)
+	for std, s := range r.standards {
+		if s.name == name {
+			return 0, &RegistrationError{name, ErrUnitAlreadyRegistered}
+		}
+		if std >= next {
+			next = std + 1
+		}
+	}
+	r.standards[next] = standardRadix{name, radix, step}
+	r.pairs = append(r.pairs, NewBaseUnitSymbolPair(next))
+	return next, nil
+}
+
+// Register registers a UnitSymbolPair for a registered standard. Neither its
+// exponent nor its symbols may already be registered for that standard, while
+// symbols registered for another standard are allowed, in which case the
+// earliest registration is used by FindStandardBySymbol and Parse
+func (r *Registry) Register(pair UnitSymbolPair) error {
+	least, greatest := pair.Least(), pair.Greatest()
+	if least == "" || greatest == "" {
+		return NewErrUnitSymbolNotSupported("")
+	}
+	if least == greatest {
+		return &SymbolError{greatest, ErrUnitAlreadyRegistered}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	std := pair.Standard()
+	if _, ok := r.standards[std]; !ok {
+		return NewErrUnitStandardNotSupported(std)
+	}
+	for _, p := range r.pairs {
+		if p.Standard() != std {
+			continue
+		}
+		if p.Exponent() == pair.Exponent() {
+			return &RegistrationError{"exponent " + strconv.Itoa(pair.Exponent()), ErrUnitAlreadyRegistered}
+		}
+		for _, sym := range []UnitSymbol{p.Least(), p.Greatest()} {
+			if sym == least || sym == greatest {
+				return &SymbolError{sym, ErrUnitAlreadyRegistered}
+			}
+		}
+	}
+	r.pairs = append(r.pairs, pair)
+	return nil
+}
+
+// Pairs returns a copy of the registered UnitSymbolPairs, in order of
+// registration
+func (r *Registry) Pairs() []UnitSymbolPair {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	pairs := make([]UnitSymbolPair, len(r.pairs))
+	copy(pairs, r.pairs)
+	return pairs
+}
+
+// StandardName returns the name of a registered UnitStandard, or false if it
+// is not registered
+func (r *Registry) StandardName(std UnitStandard) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.standards[std]
+	return s.name, ok
+}
+
+// FindStandardByName returns the registered UnitStandard of a given name, or
+// false if none is registered
+func (r *Registry) FindStandardByName(name string) (UnitStandard, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for std, s := range r.standards {
+		if s.name == name {
+			return std, true
+		}
+	}
+	return UnitStandard(0), false
+}
+
+// radix returns the radix and step of a registered UnitStandard
+func (r *Registry) radix(std UnitStandard) (int, int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.standards[std]
+	return s.radix, s.step, ok
+}
+
+// FindUnitSymbolPairBySymbol takes a UnitStandard and a symbol in order to
+// find and return the UnitSymbolPair for that standard and symbol, or false
+// if the UnitSymbolPair cannot be found.
+func (r *Registry) FindUnitSymbolPairBySymbol(std UnitStandard, sym UnitSymbol) (UnitSymbolPair, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, p := range r.pairs {
+		if p.Standard() == std && (p.Least() == sym || p.Greatest() == sym) {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// FindUnitSymbolPairByExponent takes a UnitStandard and an exponent in order to
+// find and return the UnitSymbolPair for that standard and exponent, or false
+// if the UnitSymbolPair cannot be found.
+func (r *Registry) FindUnitSymbolPairByExponent(std UnitStandard, exp int) (UnitSymbolPair, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, p := range r.pairs {
+		if p.Standard() == std && p.Exponent() == exp {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// FindStandardBySymbol takes a unit symbol, searches for a symbol pair that
+// matches, and returns the standard for that pair
+func (r *Registry) FindStandardBySymbol(sym UnitSymbol) (UnitStandard, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, p := range r.pairs {
+		if p.Least() == sym || p.Greatest() == sym {
+			return p.Standard(), true
+		}
+	}
+	return UnitStandard(0), false
+}
+
+// FindExponentBySymbol takes a symbol and returns the exponent
+func (r *Registry) FindExponentBySymbol(sym UnitSymbol) (int, bool) {
+	s, ok := r.FindStandardBySymbol(sym)
+	if !ok {
+		return 0, false
+	}
+	pair, ok := r.FindUnitSymbolPairBySymbol(s, sym)
+	if !ok {
+		return 0, false
+	}
+	return pair.Exponent(), true
+}
+
+// FindGreatestUnitSymbol finds the greatest of two unit symbols for a given
+// exponent by standard, or returns Byte and false if no symbol pair has that
+// exponent
+func (r *Registry) FindGreatestUnitSymbol(std UnitStandard, exp int) (UnitSymbol, bool) {
+	pair, ok := r.FindUnitSymbolPairByExponent(std, exp)
+	if !ok {
+		return Byte, false
+	}
+	return pair.Greatest(), true
+}
+
+// FindLeastUnitSymbol finds the least of two unit symbols for a given exponent
+// by standard, or returns Bit and false if no symbol pair has that exponent
+func (r *Registry) FindLeastUnitSymbol(std UnitStandard, exp int) (UnitSymbol, bool) {
+	pair, ok := r.FindUnitSymbolPairByExponent(std, exp)
+	if !ok {
+		return Bit, false
+	}
+	return pair.Least(), true
+}
+
+// FindFloorUnitSymbolPair finds the UnitSymbolPair of a standard with the
+// greatest exponent less than or equal to a given exponent, or false if there
+// is none
+func (r *Registry) FindFloorUnitSymbolPair(std UnitStandard, exp int) (UnitSymbolPair, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var floor UnitSymbolPair
+	for _, p := range r.pairs {
+		if p.Standard() != std || p.Exponent() > exp {
+			continue
+		}
+		if floor == nil || p.Exponent() > floor.Exponent() {
+			floor = p
+		}
+	}
+	return floor, floor != nil
+}
+
+// CustomUnitSymbolPair represents a unit symbol pair of any registered
+// standard, as registered with Registry.Register
+type CustomUnitSymbolPair struct {
+	standard        UnitStandard
+	least, greatest UnitSymbol
+	exponent        int
+}
+
+// NewCustomUnitSymbolPair takes a UnitStandard, the least (bit) and greatest
+// (byte) symbols, and an exponent, and returns a new UnitSymbolPair
+func NewCustomUnitSymbolPair(std UnitStandard, l, r UnitSymbol, e int) UnitSymbolPair {
+	return &CustomUnitSymbolPair{standard: std, least: l, greatest: r, exponent: e}
+}
+
+// Standard returns the UnitStandard of a CustomUnitSymbolPair
+func (pair *CustomUnitSymbolPair) Standard() UnitStandard {
+	return pair.standard
+}
+
+// Exponent returns the exponent of a CustomUnitSymbolPair
+func (pair *CustomUnitSymbolPair) Exponent() int {
+	return pair.exponent
+}
+
+// Least returns the least UnitSymbol of a CustomUnitSymbolPair
+func (pair *CustomUnitSymbolPair) Least() UnitSymbol {
+	return pair.least
+}
+
+// Greatest returns the greatest UnitSymbol of a CustomUnitSymbolPair
+func (pair *CustomUnitSymbolPair) Greatest() UnitSymbol {
+	return pair.greatest
+}
@@ -0,0 +1,171 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleRegisterStandard() {
+	// Package level functions resolve standards through DefaultRegistry,
+	// which is replaced for the duration of the example in order to keep the
+	// registration from leaking
+	defer func(r *Registry) { DefaultRegistry = r }(DefaultRegistry)
+	DefaultRegistry = NewDefaultRegistry()
+
+	// A standard of radix 2 and step 1 measures the pair of exponent e in
+	// 2^e bytes
+	storage, _ := RegisterStandard("storage", 2, 1)
+	_ = Register(NewCustomUnitSymbolPair(storage, "sectorbit", "sector", 9))
+	_ = Register(NewCustomUnitSymbolPair(storage, "pagebit", "page", 12))
+	_ = Register(NewCustomUnitSymbolPair(storage, "blockbit", "block", 26))
+	u, _ := Parse("1048576 sector")
+	fmt.Println(u, u.ByteSize())
+	fmt.Println(Normalize(u))
+	pages, _ := ConvertTo(u, "page")
+	fmt.Println(pages)
+	fmt.Println(u.Standard())
+	// Output:
+	// 1048576 sector 5.36870912e+08
+	// 8 block
+	// 131072 page
+	// storage
+}
+
+func TestRegistry_RegisterStandard(t *testing.T) {
+	r := NewRegistry()
+	a, err := r.RegisterStandard("a", 2, 1)
+	assert.NoError(t, err)
+	b, err := r.RegisterStandard("b", 10, 3)
+	assert.NoError(t, err)
+	assert.NotEqual(t, a, b)
+	_, err = r.RegisterStandard("a", 2, 1)
+	assert.Error(t, err)
+	_, err = r.RegisterStandard("", 2, 1)
+	assert.Error(t, err)
+	_, err = r.RegisterStandard("c", 1, 1)
+	assert.Error(t, err)
+	_, err = r.RegisterStandard("c", 2, 0)
+	assert.Error(t, err)
+	name, ok := r.StandardName(b)
+	assert.True(t, ok)
+	assert.Equal(t, "b", name)
+	std, ok := r.FindStandardByName("a")
+	assert.True(t, ok)
+	assert.Equal(t, a, std)
+	_, ok = r.FindStandardByName("c")
+	assert.False(t, ok)
+	// The Bit and Byte pair is registered with the standard
+	pair, ok := r.FindUnitSymbolPairByExponent(b, 0)
+	assert.True(t, ok)
+	assert.Equal(t, Byte, pair.Greatest())
+}
+
+func TestRegistry_Register(t *testing.T) {
+	r := NewRegistry()
+	std, err := r.RegisterStandard("storage", 2, 1)
+	assert.NoError(t, err)
+	page := NewCustomUnitSymbolPair(std, "pagebit", "page", 12)
+	assert.NoError(t, r.Register(page))
+	assert.Error(t, r.Register(NewCustomUnitSymbolPair(std, "pgb", "pg", 12)))
+	assert.Error(t, r.Register(NewCustomUnitSymbolPair(std, "pb", "page", 13)))
+	assert.Error(t, r.Register(NewCustomUnitSymbolPair(std, "Bit", "x", 14)))
+	assert.Error(t, r.Register(NewCustomUnitSymbolPair(std, "", "x", 14)))
+	assert.Error(t, r.Register(NewCustomUnitSymbolPair(std, "x", "x", 14)))
+	assert.Error(t, r.Register(NewCustomUnitSymbolPair(UnitStandard(42), "xb", "x", 14)))
+	// Symbols of other standards may be registered again
+	assert.NoError(t, r.Register(NewCustomUnitSymbolPair(std, "Mb", "MB", 20)))
+	assert.Len(t, r.Pairs(), 3)
+
+	p, ok := r.FindUnitSymbolPairBySymbol(std, "pagebit")
+	assert.True(t, ok)
+	assert.Equal(t, page, p)
+	s, ok := r.FindStandardBySymbol("page")
+	assert.True(t, ok)
+	assert.Equal(t, std, s)
+	e, ok := r.FindExponentBySymbol("page")
+	assert.True(t, ok)
+	assert.Equal(t, 12, e)
+	g, ok := r.FindGreatestUnitSymbol(std, 12)
+	assert.True(t, ok)
+	assert.Equal(t, UnitSymbol("page"), g)
+	_, ok = r.FindGreatestUnitSymbol(std, 15)
+	assert.False(t, ok)
+	floor, ok := r.FindFloorUnitSymbolPair(std, 15)
+	assert.True(t, ok)
+	assert.Equal(t, page, floor)
+	_, ok = r.FindFloorUnitSymbolPair(std, -1)
+	assert.False(t, ok)
+	l, ok := r.FindLeastUnitSymbol(std, 12)
+	assert.True(t, ok)
+	assert.Equal(t, UnitSymbol("pagebit"), l)
+	_, ok = r.FindUnitSymbolPairBySymbol(IEC, KiB)
+	assert.False(t, ok)
+}
+
+func TestRegistry_Concurrency(t *testing.T) {
+	r := NewRegistry()
+	std, _ := r.RegisterStandard("concurrent", 2, 1)
+	var wg sync.WaitGroup
+	for i := 1; i <= 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			sym := UnitSymbol(fmt.Sprintf("u%d", i))
+			assert.NoError(t, r.Register(NewCustomUnitSymbolPair(std, sym+"b", sym, i)))
+		}(i)
+		go func() {
+			defer wg.Done()
+			r.FindUnitSymbolPairByExponent(std, 0)
+			r.Pairs()
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, r.Pairs(), 51)
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	for _, p := range unitSymbolPairs {
+		found, ok := FindUnitSymbolPairBySymbol(p.Standard(), p.Greatest())
+		assert.True(t, ok)
+		assert.Equal(t, p, found)
+	}
+	for _, std := range []UnitStandard{SI, IEC, JEDEC} {
+		parsed, err := ParseUnitStandard(std.String())
+		assert.NoError(t, err)
+		assert.Equal(t, std, parsed)
+	}
+	_, err := RegisterStandard("IEC", 2, 10)
+	assert.Error(t, err)
+	assert.Error(t, Register(NewIECUnitSymbolPair("Xib", "XiB", 1)))
+}
+
+func TestNewDefaultRegistry(t *testing.T) {
+	r := NewDefaultRegistry()
+	assert.Equal(t, DefaultRegistry.Pairs(), r.Pairs())
+	std, err := r.RegisterStandard("isolated", 2, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Register(NewCustomUnitSymbolPair(std, "isob", "iso", 9)))
+	_, ok := DefaultRegistry.FindStandardByName("isolated")
+	assert.False(t, ok)
+	_, ok = FindStandardBySymbol("iso")
+	assert.False(t, ok)
+}
@@ -1,6 +1,10 @@
 package bitty
 
-import "math"
+import (
+	"fmt"
+	"math"
+	"time"
+)
 
 /*
 	Copyright 2020 IBM
@@ -90,16 +94,7 @@ func (u *SIUnit) Size() float64 {
 
 // BitSize returns the size of the Unit measured in bits
 func (u *SIUnit) BitSize() float64 {
-	switch u.Symbol() {
-	case Bit:
-		return float64(u.ByteSize() / 8)
-	case Byte,
-		Kib, Mib, Gib, Tib, Pib, Eib, Zib, Yib,
-		KiB, MiB, GiB, TiB, PiB, EiB, ZiB, YiB:
-		return float64(u.ByteSize() * 8)
-	default:
-		return float64(0)
-	}
+	return u.ByteSize() * 8
 }
 
 // ByteSize returns the size of the Unit measured in bytes
@@ -109,94 +104,118 @@ func (u *SIUnit) ByteSize() float64 {
 
 // SizeInUnit returns the size of the Unit measured in an arbitrary UnitSymbol from Bit up to YiB or YB
 func (u *SIUnit) SizeInUnit(symbol UnitSymbol) float64 {
-	_, uok := FindUnitSymbolPairBySymbol(SI, u.Symbol())
-	p, ok := FindUnitSymbolPairBySymbol(SI, symbol)
-	if uok && ok {
-		var (
-			left    = u.ByteSize()
-			right   = UnitSymbolToByteSize(SI, symbol, u.Size())
-			diffExp = float64(u.Exponent()) - float64(p.Exponent())
-		)
-		if diffExp > 0 {
-			return right * diffExp
-		}
-		if left > 0.0 && right > 0.0 {
-			return float64((left / right) * u.Size())
-		}
+	std, ok := findStandardForSymbol(SI, symbol)
+	if !ok {
+		return float64(0)
 	}
-	return float64(0)
+	return BytesToUnitSymbolSize(std, symbol, u.ByteSize())
+}
+
+// String returns the size and symbol of a SIUnit, i.e. "1.5 GB"
+func (u *SIUnit) String() string {
+	s, _ := formatUnit(u, 'v', 0, false, false)
+	return s
+}
+
+// Format implements fmt.Formatter, supporting the verbs and flags described in
+// the package documentation
+func (u *SIUnit) Format(f fmt.State, verb rune) {
+	formatState(f, verb, u)
 }
 
 // Add attempts to add one Unit to another
 func (u *SIUnit) Add(unit Unit) Unit {
-	var (
-		nexp     int
-		nsym     UnitSymbol
-		size     float64
-		lok, rok bool
-	)
 	// Validate both sides for valid symbols
-	lok, rok = ValidateSymbols(u.Symbol(), unit.Symbol())
-	if !lok && !rok {
-		nu, _ := NewSIUnit(0, Byte)
-		return nu
-	}
+	lok, rok := ValidateSymbols(u.Symbol(), unit.Symbol())
 	if lok && !rok {
 		return u
 	}
 	if rok && !lok {
 		return unit
 	}
-	// Lets get adding
-	left := u.ByteSize()
-	right := unit.ByteSize()
-	total := left + right
-	if total > 0 {
-		nexp = int(math.Round(math.Log2(total) / 10))
+	nu, err := u.AddE(unit)
+	if err != nil {
+		nu, _ = NewSIUnit(0, Byte)
+	}
+	return nu
+}
+
+// AddE attempts to add one Unit to another, returning an error if either
+// symbol is invalid or the sum cannot be represented
+func (u *SIUnit) AddE(unit Unit) (Unit, error) {
+	var (
+		nexp int
+		nsym UnitSymbol
+		size float64
+	)
+	if err := checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
 	}
-	if u.Exponent() >= unit.Exponent() {
+	// Lets get adding
+	total := u.ByteSize() + unit.ByteSize()
+	switch {
+	case unit.Standard() != SI:
+		// The exponent of another standard may not exist in this one, so the
+		// sum is measured by the greatest symbol of a multiple of 1000 not
+		// exceeding it
+		if abs := math.Abs(total); abs >= 1 {
+			nexp = int(math.Floor(math.Log10(abs)/3)) * 3
+		}
+	case u.Exponent() >= unit.Exponent():
 		nexp = u.Exponent()
-	} else {
+	default:
 		nexp = unit.Exponent()
 	}
-	lsym, ok := FindLeastUnitSymbol(SI, nexp)
-	gsym, ok := FindGreatestUnitSymbol(SI, nexp)
+	pair, ok := FindFloorUnitSymbolPair(SI, nexp)
 	if !ok {
-		nu, _ := NewSIUnit(0, Byte)
-		return nu
+		return nil, NewErrUnitExponentNotSupported(nexp)
 	}
+	lsym, gsym := pair.Least(), pair.Greatest()
 	smallSize := BytesToUnitSymbolSize(SI, lsym, total)
 	lrgSize := BytesToUnitSymbolSize(SI, gsym, total)
-	if lrgSize < 1 {
+	if math.Abs(lrgSize) < 1 {
 		nsym = lsym
 		size = smallSize
 	} else {
 		nsym = gsym
 		size = lrgSize
 	}
-	nu, _ := NewSIUnit(size, nsym)
-	return nu
+	nu, err := NewSIUnit(size, nsym)
+	if err != nil {
+		return nil, err
+	}
+	return nu, nil
 }
 
 // Subtract attempts to subtract one Unit from another
 func (u *SIUnit) Subtract(unit Unit) Unit {
-	var (
-		ok, lok, rok, neg bool
-		total             float64
-		nexp              int
-		lsym, gsym        UnitSymbol
-	)
-	lok, rok = ValidateSymbols(u.Symbol(), unit.Symbol())
-	if !lok && !rok {
-		nu, _ := NewSIUnit(0, Byte)
-		return nu
-	}
+	lok, rok := ValidateSymbols(u.Symbol(), unit.Symbol())
 	if lok && !rok {
 		return u
 	}
 	if rok && !lok {
 		return unit
 	}
+	nu, err := u.SubtractE(unit)
+	if err != nil {
+		nu, _ = NewSIUnit(0, Byte)
+	}
+	return nu
+}
+
+// SubtractE attempts to subtract one Unit from another, returning an error if
+// either symbol is invalid or the difference cannot be represented
+func (u *SIUnit) SubtractE(unit Unit) (Unit, error) {
+	var (
+		neg   bool
+		total float64
+		nexp  int
+		nu    *SIUnit
+		err   error
+	)
+	if err = checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
+	}
 	left := u.ByteSize()
 	right := unit.ByteSize()
 	if left >= right {
@@ -206,43 +225,93 @@ func (u *SIUnit) Subtract(unit Unit) Unit {
 		neg = true
 	}
 	if total > 0 {
-		l := math.Log10(total)
-		nexp = int(math.Floor(l))
+		nexp = int(math.Floor(math.Log10(total)))
 	}
-	lsym, ok = FindLeastUnitSymbol(SI, nexp)
-	gsym, ok = FindGreatestUnitSymbol(SI, nexp)
+	// Differences of less than a byte are measured in bytes
+	if nexp < 0 {
+		nexp = 0
+	}
+	pair, ok := FindFloorUnitSymbolPair(SI, nexp)
 	if !ok {
-		nu, _ := NewSIUnit(0, Byte)
-		return nu
+		return nil, NewErrUnitExponentNotSupported(nexp)
 	}
+	lsym, gsym := pair.Least(), pair.Greatest()
 	smlSize := BytesToUnitSymbolSize(SI, lsym, total)
 	lrgSize := BytesToUnitSymbolSize(SI, gsym, total)
 	if lrgSize >= 0 {
 		if neg {
 			lrgSize = -lrgSize
 		}
-		nu, err := NewSIUnit(lrgSize, gsym)
-		if err != nil {
-			return u
+		nu, err = NewSIUnit(lrgSize, gsym)
+	} else {
+		if neg {
+			smlSize = -smlSize
 		}
-		return nu
-	}
-	if neg {
-		smlSize = -smlSize
+		nu, err = NewSIUnit(smlSize, lsym)
 	}
-	nu, err := NewSIUnit(smlSize, lsym)
 	if err != nil {
-		return u
+		return nil, err
 	}
-	return nu
+	return nu, nil
 }
 
-// Multiply attempts to multiply one Unit by another
+// Multiply attempts to multiply one Unit by another. As the product of two
+// Units has no meaningful size, a 0 Byte Unit is returned.
+//
+// Deprecated: use MultiplyE, which reports ErrUnitMultiplyNotSupported, or
+// Scale in order to multiply by a scalar
 func (u *SIUnit) Multiply(unit Unit) Unit {
-	return nil
+	nu, _ := NewSIUnit(0, Byte)
+	return nu
 }
 
-// Divide attempts to divide one Unit by another
+// Divide attempts to divide one Unit by another. As the quotient of two Units
+// is dimensionless, a 0 Byte Unit is returned.
+//
+// Deprecated: use DivideE, which reports ErrUnitDivideNotSupported, Ratio in
+// order to divide by a Unit, or Scale in order to divide by a scalar
 func (u *SIUnit) Divide(unit Unit) Unit {
-	return nil
+	nu, _ := NewSIUnit(0, Byte)
+	return nu
+}
+
+// Scale returns a new SIUnit with the same symbol and the size multiplied by n
+func (u *SIUnit) Scale(n float64) Unit {
+	return &SIUnit{u.size * n, u.symbol, u.exponent}
+}
+
+// MultiplyE returns an error, as the product of two Units has no meaningful
+// size; use Scale in order to multiply by a scalar
+func (u *SIUnit) MultiplyE(unit Unit) (Unit, error) {
+	if err := checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
+	}
+	return nil, NewErrUnitMultiplyNotSupported(u.Symbol(), unit.Symbol())
+}
+
+// DivideE returns an error, as the quotient of two Units is dimensionless; use
+// Ratio in order to divide by a Unit, or Scale in order to divide by a scalar
+func (u *SIUnit) DivideE(unit Unit) (Unit, error) {
+	if err := checkSymbols(u.Symbol(), unit.Symbol()); err != nil {
+		return nil, err
+	}
+	return nil, NewErrUnitDivideNotSupported(u.Symbol(), unit.Symbol())
+}
+
+// Ratio returns the dimensionless ratio of the SIUnit to another Unit
+func (u *SIUnit) Ratio(unit Unit) (float64, error) {
+	return DivideUnits(u, unit)
+}
+
+// Over returns the Rate of the SIUnit transferred over a duration, i.e. the
+// SIUnit per d, or the zero Rate if d is not positive, as by NewRate
+func (u *SIUnit) Over(d time.Duration) Rate {
+	r, _ := NewRate(u, d)
+	return r
+}
+
+// TransferTime returns the time taken to transfer the SIUnit at a Rate, or the
+// maximum time.Duration if the Rate is not positive
+func (u *SIUnit) TransferTime(r Rate) time.Duration {
+	return transferTime(u, r)
 }
@@ -35,7 +35,7 @@ func ExampleNewSIUnit() {
 	a, _ := NewSIUnit(10.0, MB)
 	fmt.Printf("%v\n", a)
 	// Output:
-	// &{10 MB 6}
+	// 10 MB
 }
 
 func ExampleSIUnit_ByteSize() {
@@ -55,12 +55,12 @@ func generateTestSIUnitByteSize(t *testing.T, sym UnitSymbol) testSIUnit {
 	lb := float64(math.Exp2(le) * l.Unit.size)
 	switch sym {
 	case Bit:
-		l.Expected = l.Unit.size * 8
+		l.Expected = l.Unit.size * 0.125
 	case Byte:
 		l.Expected = l.Unit.size
-	case db, hb, kb, Mb, Gb, Tb, Pb, Eb, Zb, Yb:
+	case db, dab, hb, kb, Mb, Gb, Tb, Pb, Eb, Zb, Yb, Rb, Qb:
 		l.Expected = lb * 0.125
-	case dB, hB, kB, MB, GB, TB, PB, EB, ZB, YB:
+	case dB, daB, hB, kB, MB, GB, TB, PB, EB, ZB, YB, RB, QB:
 		l.Expected = lb
 	default:
 		l.Expected = float64(0)
@@ -241,12 +241,15 @@ func generateTestSIUnitSubtract(lu, ru *SIUnit) testSIUnitSubtract {
 	if total > 0 {
 		exp = int(math.Floor(math.Log10(total)))
 	}
-	lsym, ok = FindLeastUnitSymbol(SI, exp)
-	gsym, ok = FindGreatestUnitSymbol(SI, exp)
+	if exp < 0 {
+		exp = 0
+	}
+	pair, ok := FindFloorUnitSymbolPair(SI, exp)
 	if !ok {
 		tu.expected, _ = NewSIUnit(0, Byte)
 		return tu
 	}
+	lsym, gsym = pair.Least(), pair.Greatest()
 	smlSize := BytesToUnitSymbolSize(SI, lsym, total)
 	lrgSize := BytesToUnitSymbolSize(SI, gsym, total)
 	if lrgSize > 0 {
@@ -312,3 +315,65 @@ func TestSIUnit_Subtract(t *testing.T) {
 		assert.Equal(t, tst.expected, u)
 	}
 }
+
+func ExampleSIUnit_Scale() {
+	a, _ := NewSIUnit(4, TB)
+	b := a.Scale(3)
+	fmt.Printf("%.f %s\n", b.Size(), b.Symbol())
+	// Output:
+	// 12 TB
+}
+
+func ExampleSIUnit_Ratio() {
+	a, _ := NewSIUnit(1, GB)
+	b, _ := NewSIUnit(250, MB)
+	r, _ := a.Ratio(b)
+	fmt.Println(r)
+	// Output:
+	// 4
+}
+
+func TestSIUnit_MultiplyDivide(t *testing.T) {
+	a, _ := NewSIUnit(4, GB)
+	b, _ := NewSIUnit(2, MB)
+	zero, _ := NewSIUnit(0, Byte)
+	assert.Equal(t, zero, a.Multiply(b))
+	assert.Equal(t, zero, a.Divide(b))
+	assert.Equal(t, &SIUnit{4, GB, 9}, a, "receiver is not modified")
+}
+
+func TestSIUnit_CheckedCalculator(t *testing.T) {
+	var _ CheckedCalculator = &SIUnit{}
+	var (
+		a, _ = NewSIUnit(10, MB)
+		b, _ = NewSIUnit(10.023, MB)
+		bu   = &SIUnit{1, UnitSymbol("FooBar"), 30}
+	)
+	u, err := a.AddE(b)
+	assert.NoError(t, err)
+	assert.Equal(t, &SIUnit{20.023, MB, 6}, u)
+	u, err = a.SubtractE(b)
+	assert.NoError(t, err)
+	assert.Equal(t, SI, u.Standard())
+	assert.Equal(t, kB, u.Symbol())
+	assert.InDelta(t, -23, u.Size(), 1e-6)
+	for _, f := range []func(Unit) (Unit, error){a.AddE, a.SubtractE, a.MultiplyE, a.DivideE} {
+		u, err = f(bu)
+		assert.Nil(t, u)
+		assert.EqualError(t, err, "unit symbol not supported: FooBar")
+	}
+	_, err = a.MultiplyE(b)
+	assert.EqualError(t, err, "unit multiplication by unit not supported: MB * MB")
+	_, err = a.DivideE(b)
+	assert.EqualError(t, err, "unit division by unit not supported: MB / MB")
+}
+
+func TestSIUnit_SubtractSubByte(t *testing.T) {
+	a, _ := NewSIUnit(1, Byte)
+	b, _ := NewSIUnit(0.5, Byte)
+	assert.Equal(t, &SIUnit{0.5, Byte, 0}, a.Subtract(b))
+	assert.Equal(t, &SIUnit{-0.5, Byte, 0}, b.Subtract(a))
+	c, _ := NewSIUnit(5, kB)
+	d, _ := NewSIUnit(5, daB)
+	assert.Equal(t, &SIUnit{4.95, kB, 3}, c.Subtract(d))
+}
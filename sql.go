@@ -0,0 +1,218 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+)
+
+// SQLFormat represents the form in which a Unit is stored in a database
+type SQLFormat int
+
+// SQL format enums
+const (
+	// SQLString stores a Unit as text in the grammar of Parse, i.e. "1.5 GiB",
+	// preserving its symbol and standard
+	SQLString SQLFormat = iota
+	// SQLInteger stores a Unit as its exact number of bytes, rounded to the
+	// nearest byte, i.e. 1610612736
+	SQLInteger
+)
+
+// sqlSource reads a value scanned from a database, returning either its text
+// or its number of Bytes
+func sqlSource(src interface{}) (text string, b Bytes, isText bool, err error) {
+	switch v := src.(type) {
+	case string:
+		return v, 0, true, nil
+	case []byte:
+		return string(v), 0, true, nil
+	case int64:
+		return "", Bytes(v), false, nil
+	case float64:
+		r := new(big.Rat).SetFloat64(v)
+		if r == nil {
+			return "", 0, false, ErrUnitOverflow
+		}
+		b, err = ratToBytes(r)
+		return "", b, false, err
+	}
+	return "", 0, false, fmt.Errorf("cannot scan %T into a Unit", src)
+}
+
+// Value implements driver.Valuer, storing the Unit as text, i.e. "1.5 GiB".
+// A nil or zero Unit is stored as NULL
+func (u *IECUnit) Value() (driver.Value, error) {
+	if u == nil || u.symbol == "" {
+		return nil, nil
+	}
+	return formatQualifiedUnit(u), nil
+}
+
+// Scan implements sql.Scanner. Text is parsed as by Parse and must hold an IEC
+// Unit, while integers are taken as a number of bytes measured by the greatest
+// IEC byte symbol which keeps the size at or above 1, whatever the symbol of
+// the Unit scanned into. NULL is scanned as a zero Unit
+func (u *IECUnit) Scan(src interface{}) error {
+	if src == nil {
+		*u = IECUnit{}
+		return nil
+	}
+	text, b, isText, err := sqlSource(src)
+	if err != nil {
+		return err
+	}
+	if isText {
+		return u.UnmarshalText([]byte(text))
+	}
+	nu, err := b.ToUnit(IEC)
+	if err != nil {
+		return err
+	}
+	*u = *nu.(*IECUnit)
+	return nil
+}
+
+// Value implements driver.Valuer, storing the Unit as text, i.e. "1.5 GB". A
+// nil or zero Unit is stored as NULL
+func (u *SIUnit) Value() (driver.Value, error) {
+	if u == nil || u.symbol == "" {
+		return nil, nil
+	}
+	return formatQualifiedUnit(u), nil
+}
+
+// Scan implements sql.Scanner. Text is parsed as by Parse and must hold an SI
+// Unit, while integers are taken as a number of bytes measured by the greatest
+// SI byte symbol which keeps the size at or above 1, whatever the symbol of the
+// Unit scanned into. NULL is scanned as a zero Unit
+func (u *SIUnit) Scan(src interface{}) error {
+	if src == nil {
+		*u = SIUnit{}
+		return nil
+	}
+	text, b, isText, err := sqlSource(src)
+	if err != nil {
+		return err
+	}
+	if isText {
+		return u.UnmarshalText([]byte(text))
+	}
+	nu, err := b.ToUnit(SI)
+	if err != nil {
+		return err
+	}
+	*u = *nu.(*SIUnit)
+	return nil
+}
+
+// Value implements driver.Valuer, storing the Unit as text, i.e.
+// "1.5 GB (JEDEC)". A nil or zero Unit is stored as NULL
+func (u *JEDECUnit) Value() (driver.Value, error) {
+	if u == nil || u.symbol == "" {
+		return nil, nil
+	}
+	return formatQualifiedUnit(u), nil
+}
+
+// Scan implements sql.Scanner. Text is parsed as by Parse with symbols measured
+// as JEDEC symbols, while integers are taken as a number of bytes measured by
+// the greatest JEDEC byte symbol which keeps the size at or above 1, whatever
+// the symbol of the Unit scanned into. NULL is scanned as a zero Unit
+func (u *JEDECUnit) Scan(src interface{}) error {
+	if src == nil {
+		*u = JEDECUnit{}
+		return nil
+	}
+	text, b, isText, err := sqlSource(src)
+	if err != nil {
+		return err
+	}
+	if isText {
+		return u.UnmarshalText([]byte(text))
+	}
+	nu, err := b.ToUnit(JEDEC)
+	if err != nil {
+		return err
+	}
+	*u = *nu.(*JEDECUnit)
+	return nil
+}
+
+// SQLUnit wraps a Unit of any standard, storing it in a database in the chosen
+// Format, and scanning it from either form. Text is parsed as by Parse, while
+// integers are taken as a number of bytes measured by the greatest byte symbol
+// which keeps the size at or above 1, in the standard of the current Unit or
+// else in IEC. Scanning an integer into a BigUnit keeps it a BigUnit
+type SQLUnit struct {
+	Unit
+	Format SQLFormat
+}
+
+// Value implements driver.Valuer, storing a nil Unit as NULL
+func (s SQLUnit) Value() (driver.Value, error) {
+	if s.Unit == nil {
+		return nil, nil
+	}
+	if s.Format == SQLInteger {
+		b, err := UnitToBytes(s.Unit)
+		if err != nil {
+			return nil, err
+		}
+		return int64(b), nil
+	}
+	return formatQualifiedUnit(s.Unit), nil
+}
+
+// Scan implements sql.Scanner, recording the form found in Format, and
+// scanning NULL as a nil Unit
+func (s *SQLUnit) Scan(src interface{}) error {
+	if src == nil {
+		s.Unit = nil
+		return nil
+	}
+	text, b, isText, err := sqlSource(src)
+	if err != nil {
+		return err
+	}
+	if isText {
+		u, err := Parse(text)
+		if err != nil {
+			return err
+		}
+		s.Unit, s.Format = u, SQLString
+		return nil
+	}
+	var u Unit
+	if s.Unit == nil {
+		u, err = b.ToUnit(IEC)
+	} else if bu, ok := s.Unit.(*BigUnit); ok {
+		std := bu.Standard()
+		if bu, err = NewBigUnit(std, new(big.Rat).SetInt64(int64(b)), Byte); err == nil {
+			u, err = bu.ConvertStd(std)
+		}
+	} else {
+		u, err = b.ToUnit(s.Unit.Standard())
+	}
+	if err != nil {
+		return err
+	}
+	s.Unit, s.Format = u, SQLInteger
+	return nil
+}
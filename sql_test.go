@@ -0,0 +1,172 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ sql.Scanner   = &IECUnit{}
+	_ driver.Valuer = &IECUnit{}
+	_ sql.Scanner   = &SIUnit{}
+	_ driver.Valuer = &SIUnit{}
+	_ sql.Scanner   = &SQLUnit{}
+	_ driver.Valuer = SQLUnit{}
+)
+
+func ExampleSQLUnit() {
+	quota, _ := NewIECUnit(1.5, GiB)
+	v, _ := SQLUnit{quota, SQLInteger}.Value()
+	fmt.Println(v)
+	// Integers are normalized, whatever the symbol of the Unit scanned into
+	s := SQLUnit{Unit: &IECUnit{0, MiB, 2}}
+	_ = s.Scan(v)
+	fmt.Println(s.Unit)
+	// Output:
+	// 1610612736
+	// 1.5 GiB
+}
+
+type testSQLScan struct {
+	src      interface{}
+	expected Unit
+	err      bool
+}
+
+func TestIECUnit_Scan(t *testing.T) {
+	tt := []testSQLScan{
+		{"1.5 GiB", &IECUnit{1.5, GiB, 3}, false},
+		{[]byte("512MiB"), &IECUnit{512, MiB, 2}, false},
+		{int64(1536), &IECUnit{1.5, KiB, 1}, false},
+		{float64(2048), &IECUnit{2, KiB, 1}, false},
+		{nil, &IECUnit{}, false},
+		{"1.5 GB", nil, true},
+		{true, nil, true},
+	}
+	for _, test := range tt {
+		u := IECUnit{1, MiB, 2}
+		switch test.src.(type) {
+		case int64, float64:
+			u = IECUnit{}
+		}
+		err := u.Scan(test.src)
+		if test.err {
+			assert.Error(t, err, "%v", test.src)
+			continue
+		}
+		assert.NoError(t, err, "%v", test.src)
+		assert.Equal(t, test.expected, &u, "%v", test.src)
+	}
+	u := IECUnit{1, GiB, 3}
+	assert.NoError(t, u.Scan(int64(1024)))
+	assert.Equal(t, IECUnit{1, KiB, 1}, u, "integers do not keep a stale symbol")
+}
+
+func TestSIUnit_Scan(t *testing.T) {
+	tt := []testSQLScan{
+		{"1.5 GB", &SIUnit{1.5, GB, 9}, false},
+		{[]byte("512MB"), &SIUnit{512, MB, 6}, false},
+		{int64(1500), &SIUnit{1.5, kB, 3}, false},
+		{nil, &SIUnit{}, false},
+		{"1.5 GiB", nil, true},
+		{int(10), nil, true},
+	}
+	for _, test := range tt {
+		var u SIUnit
+		err := u.Scan(test.src)
+		if test.err {
+			assert.Error(t, err, "%v", test.src)
+			continue
+		}
+		assert.NoError(t, err, "%v", test.src)
+		assert.Equal(t, test.expected, &u, "%v", test.src)
+	}
+}
+
+func TestUnit_Value(t *testing.T) {
+	v, err := (&IECUnit{1.5, GiB, 3}).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5 GiB", v)
+	v, err = (&SIUnit{10, MB, 6}).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "10 MB", v)
+	v, err = (&IECUnit{512, Byte, 0}).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "512 Byte (IEC)", v)
+	var nilUnit *IECUnit
+	v, err = nilUnit.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+	v, err = (&SIUnit{}).Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestSQLUnit(t *testing.T) {
+	bu, _ := NewBigUnit(SI, big.NewRat(7, 4), GB)
+	units := []Unit{
+		&IECUnit{1.5, GiB, 3},
+		&SIUnit{3, Mb, 6},
+		&IECUnit{512, Byte, 0},
+		&JEDECUnit{16, GB, 3},
+		bu,
+	}
+	for _, u := range units {
+		for _, format := range []SQLFormat{SQLString, SQLInteger} {
+			v, err := SQLUnit{u, format}.Value()
+			assert.NoError(t, err, "%v", u)
+			if format == SQLInteger {
+				assert.IsType(t, int64(0), v, "%v", u)
+			}
+			// Integers are normalized in the standard of the Unit scanned into
+			s := SQLUnit{Unit: u}
+			if format == SQLString {
+				s.Unit = nil
+			}
+			assert.NoError(t, s.Scan(v), "%v", u)
+			assert.Equal(t, format, s.Format, "%v", u)
+			assert.True(t, Equal(u, s.Unit), "%v", u)
+			assert.Equal(t, u.Standard(), s.Standard(), "%v", u)
+			if format == SQLInteger {
+				assert.IsType(t, u, s.Unit, "%v", u)
+				e, _ := Bytes(v.(int64)).ToUnit(u.Standard())
+				assert.Equal(t, e.Symbol(), s.Symbol(), "%v", u)
+			} else {
+				assert.Equal(t, u.Symbol(), s.Symbol(), "%v", u)
+			}
+		}
+	}
+	var s SQLUnit
+	assert.NoError(t, s.Scan(int64(1536)))
+	assert.Equal(t, &IECUnit{1.5, KiB, 1}, s.Unit)
+	s = SQLUnit{Unit: &IECUnit{1, GiB, 3}}
+	assert.NoError(t, s.Scan(int64(1024)))
+	assert.Equal(t, &IECUnit{1, KiB, 1}, s.Unit)
+	assert.NoError(t, s.Scan(nil))
+	assert.Nil(t, s.Unit)
+	v, err := s.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+	assert.Error(t, s.Scan("ten GiB"))
+}
@@ -0,0 +1,130 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+// MarshalText implements encoding.TextMarshaler, i.e. "1.5 GiB", marshaling
+// the zero IECUnit as "0 Byte (IEC)"
+func (u *IECUnit) MarshalText() ([]byte, error) {
+	return []byte(formatQualifiedUnit(u)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the format of
+// Parse, i.e. "512MiB" or "512 MiB"
+func (u *IECUnit) UnmarshalText(text []byte) error {
+	nu, err := parseUnitWithin(string(text), IEC)
+	if err != nil {
+		return err
+	}
+	*u = *nu.(*IECUnit)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, i.e. "1.5 GB", marshaling
+// the zero SIUnit as "0 Byte"
+func (u *SIUnit) MarshalText() ([]byte, error) {
+	return []byte(formatQualifiedUnit(u)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the format of
+// Parse, i.e. "512MB" or "512 MB"
+func (u *SIUnit) UnmarshalText(text []byte) error {
+	nu, err := parseUnitWithin(string(text), SI)
+	if err != nil {
+		return err
+	}
+	*u = *nu.(*SIUnit)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, i.e. "1.5 GB (JEDEC)",
+// marshaling the zero JEDECUnit as "0 Byte (JEDEC)"
+func (u *JEDECUnit) MarshalText() ([]byte, error) {
+	return []byte(formatQualifiedUnit(u)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the format of
+// Parse, i.e. "512MB" or "512 MB", with symbols measured as JEDEC symbols
+func (u *JEDECUnit) UnmarshalText(text []byte) error {
+	nu, err := parseUnitWithin(string(text), JEDEC)
+	if err != nil {
+		return err
+	}
+	*u = *nu.(*JEDECUnit)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, i.e. "SI", "IEC" or "JEDEC"
+func (s UnitStandard) MarshalText() ([]byte, error) {
+	if _, ok := FindUnitSymbolPairByExponent(s, 0); !ok {
+		return nil, NewErrUnitStandardNotSupported(s)
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the name of a
+// UnitStandard with ParseUnitStandard
+func (s *UnitStandard) UnmarshalText(text []byte) error {
+	std, err := ParseUnitStandard(string(text))
+	if err != nil {
+		return err
+	}
+	*s = std
+	return nil
+}
+
+// Size holds a Unit of any standard which is marshaled to and from text with
+// Parse, so that sizes like "512MiB" can be used by any configuration format
+// or library relying on encoding.TextUnmarshaler (YAML, TOML, environment
+// variables, ...)
+type Size struct {
+	Unit
+}
+
+// String returns the size and symbol of the Unit, or an empty string if the
+// Unit is nil
+func (s Size) String() string {
+	if s.Unit == nil {
+		return ""
+	}
+	str, _ := formatUnit(s.Unit, 'v', 0, false, false)
+	return str
+}
+
+// MarshalText implements encoding.TextMarshaler, marshaling a nil Unit as
+// empty text. Symbols which Parse would measure in another standard are
+// qualified by the standard of the Unit, i.e. "16 GB (JEDEC)"
+func (s Size) MarshalText() ([]byte, error) {
+	if s.Unit == nil {
+		return []byte{}, nil
+	}
+	return []byte(formatQualifiedUnit(s.Unit)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the text with
+// Parse, or unmarshaling empty text as a nil Unit
+func (s *Size) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		s.Unit = nil
+		return nil
+	}
+	u, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	s.Unit = u
+	return nil
+}
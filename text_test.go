@@ -0,0 +1,165 @@
+package bitty
+
+/*
+	Copyright 2020 IBM
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+import (
+	"encoding"
+	"encoding/xml"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ encoding.TextMarshaler   = &IECUnit{}
+	_ encoding.TextUnmarshaler = &IECUnit{}
+	_ encoding.TextMarshaler   = &SIUnit{}
+	_ encoding.TextUnmarshaler = &SIUnit{}
+	_ encoding.TextMarshaler   = Size{}
+	_ encoding.TextUnmarshaler = &Size{}
+)
+
+func ExampleSize() {
+	var config struct {
+		CacheSize Size `xml:"cache_size,attr"`
+	}
+	_ = xml.Unmarshal([]byte(`<config cache_size="512MiB"/>`), &config)
+	fmt.Println(config.CacheSize, config.CacheSize.ByteSize())
+	// Output:
+	// 512 MiB 5.36870912e+08
+}
+
+type testUnmarshalText struct {
+	in       string
+	expected Unit
+	err      bool
+}
+
+func TestIECUnit_UnmarshalText(t *testing.T) {
+	tt := []testUnmarshalText{
+		{"512MiB", &IECUnit{512, MiB, 2}, false},
+		{"1.5 GiB", &IECUnit{1.5, GiB, 3}, false},
+		{"8 Bit", &IECUnit{8, Bit, 0}, false},
+		{"512 Byte (IEC)", &IECUnit{512, Byte, 0}, false},
+		{"512 Byte (SI)", nil, true},
+		{"8 bit", nil, true},
+		{"1.5 GB", nil, true},
+		{"", nil, true},
+		{"MiB", nil, true},
+	}
+	for _, test := range tt {
+		var u IECUnit
+		err := u.UnmarshalText([]byte(test.in))
+		if test.err {
+			assert.Error(t, err, test.in)
+			continue
+		}
+		assert.NoError(t, err, test.in)
+		assert.Equal(t, test.expected, &u, test.in)
+		b, err := u.MarshalText()
+		assert.NoError(t, err, test.in)
+		var rt IECUnit
+		assert.NoError(t, rt.UnmarshalText(b), test.in)
+		assert.Equal(t, u, rt, test.in)
+	}
+}
+
+func TestSIUnit_UnmarshalText(t *testing.T) {
+	tt := []testUnmarshalText{
+		{"512MB", &SIUnit{512, MB, 6}, false},
+		{"1.5 kb", &SIUnit{1.5, kb, 3}, false},
+		{"8 Byte", &SIUnit{8, Byte, 0}, false},
+		{"1.5 GiB", nil, true},
+		{"", nil, true},
+	}
+	for _, test := range tt {
+		var u SIUnit
+		err := u.UnmarshalText([]byte(test.in))
+		if test.err {
+			assert.Error(t, err, test.in)
+			continue
+		}
+		assert.NoError(t, err, test.in)
+		assert.Equal(t, test.expected, &u, test.in)
+		b, err := u.MarshalText()
+		assert.NoError(t, err, test.in)
+		var rt SIUnit
+		assert.NoError(t, rt.UnmarshalText(b), test.in)
+		assert.Equal(t, u, rt, test.in)
+	}
+}
+
+func TestUnit_ZeroText(t *testing.T) {
+	tt := []struct {
+		zero, rt encoding.TextUnmarshaler
+		text     string
+		expected Unit
+	}{
+		{&IECUnit{}, &IECUnit{}, "0 Byte (IEC)", &IECUnit{0, Byte, 0}},
+		{&SIUnit{}, &SIUnit{}, "0 Byte", &SIUnit{0, Byte, 0}},
+		{&JEDECUnit{}, &JEDECUnit{}, "0 Byte (JEDEC)", &JEDECUnit{0, Byte, 0}},
+	}
+	for _, test := range tt {
+		b, err := test.zero.(encoding.TextMarshaler).MarshalText()
+		assert.NoError(t, err)
+		assert.Equal(t, test.text, string(b))
+		assert.NoError(t, test.rt.UnmarshalText(b), test.text)
+		assert.Equal(t, test.expected, test.rt, test.text)
+	}
+}
+
+func TestSize_Text(t *testing.T) {
+	tt := []testUnmarshalText{
+		{"512MiB", &IECUnit{512, MiB, 2}, false},
+		{"2 TB", &SIUnit{2, TB, 12}, false},
+		{"512 Byte (IEC)", &IECUnit{512, Byte, 0}, false},
+		{"16 GB (JEDEC)", &JEDECUnit{16, GB, 3}, false},
+		{"512 Byte (FOO)", nil, true},
+		{"", nil, false},
+		{"2 XB", nil, true},
+	}
+	for _, test := range tt {
+		var s Size
+		err := s.UnmarshalText([]byte(test.in))
+		if test.err {
+			assert.Error(t, err, test.in)
+			continue
+		}
+		assert.NoError(t, err, test.in)
+		assert.Equal(t, test.expected, s.Unit, test.in)
+		b, err := s.MarshalText()
+		assert.NoError(t, err, test.in)
+		var rt Size
+		assert.NoError(t, rt.UnmarshalText(b), test.in)
+		assert.Equal(t, s, rt, test.in)
+	}
+}
+
+func TestUnitStandard_Text(t *testing.T) {
+	for _, std := range []UnitStandard{SI, IEC} {
+		b, err := std.MarshalText()
+		assert.NoError(t, err)
+		var rt UnitStandard
+		assert.NoError(t, rt.UnmarshalText(b))
+		assert.Equal(t, std, rt)
+	}
+	_, err := UnitStandard(42).MarshalText()
+	assert.Error(t, err)
+	var s UnitStandard
+	assert.Error(t, s.UnmarshalText([]byte("FOO")))
+}
@@ -16,6 +16,8 @@ package bitty
 	limitations under the License.
 */
 
+import "strconv"
+
 // UnitSymbol represents the measurement symbol of a binary measurement as dictated by the SI
 type UnitSymbol string
 
@@ -31,6 +33,8 @@ const (
 	Eib  UnitSymbol = "Eib"
 	Zib  UnitSymbol = "Zib"
 	Yib  UnitSymbol = "Yib"
+	Rib  UnitSymbol = "Rib"
+	Qib  UnitSymbol = "Qib"
 	KiB  UnitSymbol = "KiB"
 	MiB  UnitSymbol = "MiB"
 	GiB  UnitSymbol = "GiB"
@@ -39,7 +43,10 @@ const (
 	EiB  UnitSymbol = "EiB"
 	ZiB  UnitSymbol = "ZiB"
 	YiB  UnitSymbol = "YiB"
+	RiB  UnitSymbol = "RiB"
+	QiB  UnitSymbol = "QiB"
 	db   UnitSymbol = "db"
+	dab  UnitSymbol = "dab"
 	hb   UnitSymbol = "hb"
 	kb   UnitSymbol = "kb"
 	Mb   UnitSymbol = "Mb"
@@ -49,7 +56,10 @@ const (
 	Eb   UnitSymbol = "Eb"
 	Zb   UnitSymbol = "Zb"
 	Yb   UnitSymbol = "Yb"
+	Rb   UnitSymbol = "Rb"
+	Qb   UnitSymbol = "Qb"
 	dB   UnitSymbol = "dB"
+	daB  UnitSymbol = "daB"
 	hB   UnitSymbol = "hB"
 	kB   UnitSymbol = "kB"
 	MB   UnitSymbol = "MB"
@@ -59,19 +69,96 @@ const (
 	EB   UnitSymbol = "EB"
 	ZB   UnitSymbol = "ZB"
 	YB   UnitSymbol = "YB"
+	RB   UnitSymbol = "RB"
+	QB   UnitSymbol = "QB"
+	Kb   UnitSymbol = "Kb"
+	KB   UnitSymbol = "KB"
 )
 
+// unitSymbolNames holds the singular long name of each UnitSymbol
+var unitSymbolNames = map[UnitSymbol]string{
+	Bit:  "bit",
+	Byte: "byte",
+	Kib:  "kibibit",
+	Mib:  "mebibit",
+	Gib:  "gibibit",
+	Tib:  "tebibit",
+	Pib:  "pebibit",
+	Eib:  "exbibit",
+	Zib:  "zebibit",
+	Yib:  "yobibit",
+	Rib:  "robibit",
+	Qib:  "quebibit",
+	KiB:  "kibibyte",
+	MiB:  "mebibyte",
+	GiB:  "gibibyte",
+	TiB:  "tebibyte",
+	PiB:  "pebibyte",
+	EiB:  "exbibyte",
+	ZiB:  "zebibyte",
+	YiB:  "yobibyte",
+	RiB:  "robibyte",
+	QiB:  "quebibyte",
+	db:   "decibit",
+	dab:  "decabit",
+	hb:   "hectobit",
+	kb:   "kilobit",
+	Mb:   "megabit",
+	Gb:   "gigabit",
+	Tb:   "terabit",
+	Pb:   "petabit",
+	Eb:   "exabit",
+	Zb:   "zettabit",
+	Yb:   "yottabit",
+	Rb:   "ronnabit",
+	Qb:   "quettabit",
+	dB:   "decibyte",
+	daB:  "decabyte",
+	hB:   "hectobyte",
+	kB:   "kilobyte",
+	MB:   "megabyte",
+	GB:   "gigabyte",
+	TB:   "terabyte",
+	PB:   "petabyte",
+	EB:   "exabyte",
+	ZB:   "zettabyte",
+	YB:   "yottabyte",
+	RB:   "ronnabyte",
+	QB:   "quettabyte",
+	Kb:   "kilobit",
+	KB:   "kilobyte",
+}
+
 // UnitStandard represents a standard for unit measurement. Currently SI 9th
 // edition is the supported standard, with SI notation for IEC binary and
-// decimal formats
+// decimal formats, along with the JEDEC 100B.01 binary memory format
 type UnitStandard int
 
 // Unit Standard enums
 const (
 	SI UnitStandard = iota
 	IEC
+	JEDEC
 )
 
+// String returns the name of a UnitStandard, i.e. "SI", "IEC" or "JEDEC", or
+// its number if it is not registered in the DefaultRegistry
+func (s UnitStandard) String() string {
+	if name, ok := DefaultRegistry.StandardName(s); ok {
+		return name
+	}
+	return strconv.Itoa(int(s))
+}
+
+// ParseUnitStandard parses the name of a UnitStandard registered in the
+// DefaultRegistry, i.e. "SI", "IEC" or "JEDEC"
+func ParseUnitStandard(s string) (UnitStandard, error) {
+	if std, ok := DefaultRegistry.FindStandardByName(s); ok {
+		return std, nil
+	}
+	return UnitStandard(0), &ParseError{s, 0, ComponentStandard, ErrUnitStandardNotSupported}
+}
+
 // UnitSymbolPair holds the least and greatest UnitSymbol for a given standard
 // and exponent
 type UnitSymbolPair interface {
@@ -92,7 +179,11 @@ var unitSymbolPairs = []UnitSymbolPair{
 	NewIECUnitSymbolPair(Eib, EiB, 6),
 	NewIECUnitSymbolPair(Zib, ZiB, 7),
 	NewIECUnitSymbolPair(Yib, YiB, 8),
-	NewSIUnitSymbolPair(db, dB, 1),
+	// Rib and Qib follow the IEC proposal matching the 2022 SI prefixes
+	NewIECUnitSymbolPair(Rib, RiB, 9),
+	NewIECUnitSymbolPair(Qib, QiB, 10),
+	NewSIUnitSymbolPair(db, dB, -1),
+	NewSIUnitSymbolPair(dab, daB, 1),
 	NewSIUnitSymbolPair(hb, hB, 2),
 	NewSIUnitSymbolPair(kb, kB, 3),
 	NewSIUnitSymbolPair(Mb, MB, 6),
@@ -102,4 +193,13 @@ var unitSymbolPairs = []UnitSymbolPair{
 	NewSIUnitSymbolPair(Eb, EB, 18),
 	NewSIUnitSymbolPair(Zb, ZB, 21),
 	NewSIUnitSymbolPair(Yb, YB, 24),
+	NewSIUnitSymbolPair(Rb, RB, 27),
+	NewSIUnitSymbolPair(Qb, QB, 30),
+	// JEDEC pairs come last, so that the symbols shared with SI (MB, GB, ...)
+	// are found as SI symbols by default
+	NewBaseUnitSymbolPair(JEDEC),
+	NewJEDECUnitSymbolPair(Kb, KB, 1),
+	NewJEDECUnitSymbolPair(Mb, MB, 2),
+	NewJEDECUnitSymbolPair(Gb, GB, 3),
+	NewJEDECUnitSymbolPair(Tb, TB, 4),
 }
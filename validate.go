@@ -15,3 +15,16 @@ func ValidateSymbol(sym UnitSymbol) bool {
 func ValidateSymbols(l, r UnitSymbol) (bool, bool) {
 	return ValidateSymbol(l), ValidateSymbol(r)
 }
+
+// checkSymbols validates both symbols, returning an error for the first
+// invalid symbol found
+func checkSymbols(l, r UnitSymbol) error {
+	lok, rok := ValidateSymbols(l, r)
+	if !lok {
+		return NewErrUnitSymbolNotSupported(l)
+	}
+	if !rok {
+		return NewErrUnitSymbolNotSupported(r)
+	}
+	return nil
+}